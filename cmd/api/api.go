@@ -15,7 +15,9 @@ import (
 )
 
 const (
-	flagPort = "port"
+	flagPort               = "port"
+	flagCursorSecret       = "cursor_secret"
+	flagRandomCursorSecret = "random_cursor_secret"
 )
 
 var (
@@ -30,6 +32,12 @@ var (
 func init() {
 	ServiceCmd.Flags().IntP(flagPort, "p", 8080, "The port for the api service to listen to")
 	viper.BindPFlag("PORT", ServiceCmd.Flags().Lookup(flagPort))
+
+	ServiceCmd.Flags().String(flagCursorSecret, "", "Secret used to sign search and change log cursors. Required unless random_cursor_secret is set.")
+	viper.BindPFlag("CURSOR_SECRET", ServiceCmd.Flags().Lookup(flagCursorSecret))
+
+	ServiceCmd.Flags().Bool(flagRandomCursorSecret, false, "Sign cursors with a random secret when cursor_secret is not set, for local development. Cursors do not survive a restart.")
+	viper.BindPFlag("RANDOM_CURSOR_SECRET", ServiceCmd.Flags().Lookup(flagRandomCursorSecret))
 }
 
 func run(cmd *cobra.Command, _ []string) error {
@@ -40,8 +48,18 @@ func run(cmd *cobra.Command, _ []string) error {
 
 	port := viper.GetInt(flagPort)
 
+	// a random secret invalidates every outstanding cursor on each restart or deploy
+	cursorSecret := viper.GetString(flagCursorSecret)
+	if cursorSecret == "" && !viper.GetBool(flagRandomCursorSecret) {
+		return fmt.Errorf("%s (CURSOR_SECRET) is required, set %s for local development", flagCursorSecret, flagRandomCursorSecret)
+	}
+
+	apiService, err := api.NewGenconBuddyAPI(&gcb.Logger, gcb.EventRepo, gcb.ChangeLogRepo, gcb.SavedSearchRepo, gcb.TicketRepo, port, cursorSecret)
+	if err != nil {
+		return fmt.Errorf("failed to create the api service: %w", err)
+	}
+
 	mainCtx, mainCancel := context.WithCancel(context.Background())
-	apiService := api.NewGenconBuddyAPI(&gcb.Logger, gcb.EventRepo, gcb.ChangeLogRepo, port)
 
 	gracefullShutdown := make(chan os.Signal, 1)
 	signal.Notify(gracefullShutdown, syscall.SIGINT, syscall.SIGTERM)
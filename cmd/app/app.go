@@ -10,6 +10,9 @@ import (
 
 	"github.com/gencon_buddy_api/internal/changelog"
 	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/savedsearch"
+	"github.com/gencon_buddy_api/internal/tickets"
+	"github.com/gencon_buddy_api/internal/webhook"
 )
 
 type appContextKey uint
@@ -20,21 +23,29 @@ const (
 
 // App holds relevant information for the gcb cli app
 type App struct {
-	Logger        zerolog.Logger
-	OSClient      *opensearch.Client
-	EventRepo     *event.EventRepo
-	ChangeLogRepo *changelog.Repo
-	BatchSize     int
+	Logger          zerolog.Logger
+	OSClient        *opensearch.Client
+	EventRepo       *event.EventRepo
+	ChangeLogRepo   *changelog.Repo
+	SavedSearchRepo *savedsearch.Repo
+	TicketRepo      *tickets.Repo
+	WebhookRepo     *webhook.Repo
+	BatchSize       int
 }
 
 // AppConfig contains all configuration needed to initialize the GCB App
 type AppConfig struct {
-	OSAddress      string
-	OSUsername     string
-	OSPassword     string
-	EventIndex     string
-	ChangeLogIndex string
-	BatchSize      int
+	OSAddress         string
+	OSUsername        string
+	OSPassword        string
+	EventIndex        string
+	ChangeLogIndex    string
+	SavedSearchIndex  string
+	NotificationIndex string
+	TicketIndex       string
+	WebhookIndex      string
+	DeliveryIndex     string
+	BatchSize         int
 }
 
 // NewApp initializes the shared GCP App
@@ -56,11 +67,14 @@ func NewApp(logger zerolog.Logger, config AppConfig) (*App, error) {
 	}
 
 	return &App{
-		Logger:        logger,
-		OSClient:      client,
-		EventRepo:     event.NewEventRepo(&logger, client, config.BatchSize, config.EventIndex),
-		ChangeLogRepo: changelog.NewRepo(&logger, client, config.BatchSize, config.ChangeLogIndex),
-		BatchSize:     config.BatchSize,
+		Logger:          logger,
+		OSClient:        client,
+		EventRepo:       event.NewEventRepo(&logger, client, config.BatchSize, config.EventIndex),
+		ChangeLogRepo:   changelog.NewRepo(&logger, client, config.BatchSize, config.ChangeLogIndex),
+		SavedSearchRepo: savedsearch.NewRepo(&logger, client, config.BatchSize, config.SavedSearchIndex, config.NotificationIndex),
+		TicketRepo:      tickets.NewRepo(&logger, client, config.TicketIndex),
+		WebhookRepo:     webhook.NewRepo(&logger, client, config.BatchSize, config.WebhookIndex, config.DeliveryIndex),
+		BatchSize:       config.BatchSize,
 	}, nil
 }
 
@@ -27,8 +27,8 @@ var (
 )
 
 func init() {
-	Cmd.PersistentFlags().BoolP(cleanFlag, "c", false, "cleans all indicies before initilizing the data")
-	Cmd.PersistentFlags().StringP(filepathFlag, "f", "", "the filepath of the csv event data to load")
+	Cmd.PersistentFlags().BoolP(cleanFlag, "c", false, "cleans the event and change log indicies before initilizing the data")
+	Cmd.PersistentFlags().StringP(filepathFlag, "f", "", "the filepath of the csv event data to load, init only sets up the indices without one")
 	Cmd.PersistentFlags().String(flagBGGMapping, "", "path to bgg_mapping.json produced by match-bgg")
 
 	Cmd.AddCommand(initialize.InitCmd)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -20,7 +21,7 @@ var (
 	InitCmd = &cobra.Command{
 		Use:   "init",
 		Short: "Initialize the OpenSearch mapping template and indices.",
-		Long:  "Pushes the template mapping using the latest schema. And checks if the relevant indices exist. If not, it creates those.",
+		Long:  "Pushes the template mapping using the latest schema. And checks if the relevant indices exist. If not, it creates those, otherwise it adds any new analyzers and fields to them.",
 		RunE:  run,
 	}
 
@@ -29,6 +30,21 @@ var (
 
 	//go:embed schema/change_log_index.json
 	changeLogIndexFile []byte
+
+	//go:embed schema/saved_search_index.json
+	savedSearchIndexFile []byte
+
+	//go:embed schema/saved_search_notification_index.json
+	notificationIndexFile []byte
+
+	//go:embed schema/ticket_snapshot_index.json
+	ticketSnapshotIndexFile []byte
+
+	//go:embed schema/webhook_endpoint_index.json
+	webhookIndexFile []byte
+
+	//go:embed schema/webhook_delivery_index.json
+	webhookDeliveryIndexFile []byte
 )
 
 func run(cmd *cobra.Command, _ []string) error {
@@ -60,24 +76,86 @@ func run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	if clean {
-		eventIndex, err := cmd.Flags().GetString("event_index")
-		if err != nil {
-			return fmt.Errorf("failed to read persistent flag event_index: %w", err)
-		}
+	eventIndex, err := cmd.Flags().GetString("event_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag event_index: %w", err)
+	}
 
+	changeLogIndex, err := cmd.Flags().GetString("change_log_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag change log index: %w", err)
+	}
+
+	if clean {
 		if err := cleanIndex(cmd.Context(), gcb, eventIndex, eventIndexFile); err != nil {
 			return fmt.Errorf("failed to clean and create the event index: %w", err)
 		}
 
-		changeLogIndex, err := cmd.Flags().GetString("change_log_index")
-		if err != nil {
-			return fmt.Errorf("failed to read persistent flag change log index: %w", err)
-		}
-
 		if err := cleanIndex(cmd.Context(), gcb, changeLogIndex, changeLogIndexFile); err != nil {
 			return fmt.Errorf("failed to clean and create the change log index: %w", err)
 		}
+	} else {
+		// the change log is the history of the season, so existing indices are migrated to the latest schema
+		if err := ensureIndex(cmd.Context(), gcb, eventIndex, eventIndexFile); err != nil {
+			return fmt.Errorf("failed to create or update the event index: %w", err)
+		}
+
+		if err := ensureIndex(cmd.Context(), gcb, changeLogIndex, changeLogIndexFile); err != nil {
+			return fmt.Errorf("failed to create or update the change log index: %w", err)
+		}
+	}
+
+	// saved searches and their notifications belong to users, so they are kept across a clean
+	savedSearchIndex, err := cmd.Flags().GetString("saved_search_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag saved search index: %w", err)
+	}
+
+	if err := ensureIndex(cmd.Context(), gcb, savedSearchIndex, savedSearchIndexFile); err != nil {
+		return fmt.Errorf("failed to create the saved search index: %w", err)
+	}
+
+	notificationIndex, err := cmd.Flags().GetString("notification_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag notification index: %w", err)
+	}
+
+	if err := ensureIndex(cmd.Context(), gcb, notificationIndex, notificationIndexFile); err != nil {
+		return fmt.Errorf("failed to create the saved search notification index: %w", err)
+	}
+
+	// webhook subscribers register their own endpoints, and replay reads the delivery log
+	webhookIndex, err := cmd.Flags().GetString("webhook_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag webhook index: %w", err)
+	}
+
+	if err := ensureIndex(cmd.Context(), gcb, webhookIndex, webhookIndexFile); err != nil {
+		return fmt.Errorf("failed to create the webhook endpoint index: %w", err)
+	}
+
+	webhookDeliveryIndex, err := cmd.Flags().GetString("webhook_delivery_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag webhook delivery index: %w", err)
+	}
+
+	if err := ensureIndex(cmd.Context(), gcb, webhookDeliveryIndex, webhookDeliveryIndexFile); err != nil {
+		return fmt.Errorf("failed to create the webhook delivery index: %w", err)
+	}
+
+	// ticket snapshots are the sales history, which cannot be rebuilt from the catalog
+	ticketSnapshotIndex, err := cmd.Flags().GetString("ticket_snapshot_index")
+	if err != nil {
+		return fmt.Errorf("failed to read persistent flag ticket snapshot index: %w", err)
+	}
+
+	if err := ensureIndex(cmd.Context(), gcb, ticketSnapshotIndex, ticketSnapshotIndexFile); err != nil {
+		return fmt.Errorf("failed to create the ticket snapshot index: %w", err)
+	}
+
+	if filepath == "" {
+		gcb.Logger.Info().Msg("No filepath provided, only the indices were set up")
+		return nil
 	}
 
 	var eventReader event.Reader
@@ -101,7 +179,7 @@ func run(cmd *cobra.Command, _ []string) error {
 		bggMapping = map[string]bgg.MappingEntry{}
 	}
 
-	evts, err := eventReader.ReadEvents(cmd.Context(), event.HydrateTotalTickets{}, event.NewHydrateBGG(bggMapping))
+	evts, err := eventReader.ReadEvents(cmd.Context(), event.HydrateTotalTickets{}, event.HydrateConventionTime{}, event.NewHydrateBGG(bggMapping))
 	if err != nil {
 		return fmt.Errorf("failed to read events: %w", err)
 	}
@@ -140,6 +218,200 @@ func cleanIndex(ctx context.Context, gcb *app.App, index_name string, index_sett
 		gcb.Logger.Debug().Msgf("Debug delete response: %s", resp.String())
 	}
 
+	return createIndex(ctx, gcb, index_name, index_settings)
+}
+
+// ensureIndex creates the index when it does not exist yet, otherwise it updates the existing index
+// to the latest schema, leaving its documents alone
+func ensureIndex(ctx context.Context, gcb *app.App, index_name string, index_settings []byte) error {
+	existsRequest := opensearchapi.IndicesExistsRequest{
+		Index: []string{index_name},
+	}
+
+	resp, err := existsRequest.Do(ctx, gcb.OSClient)
+	if err != nil {
+		return fmt.Errorf("failed to check if index [%s] exists: %w", index_name, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			gcb.Logger.Err(err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case 200:
+		gcb.Logger.Info().Msgf("Updating existing index: %s", index_name)
+		return updateIndex(ctx, gcb, index_name, index_settings)
+	case 404:
+		gcb.Logger.Info().Msgf("Creating index: %s", index_name)
+		return createIndex(ctx, gcb, index_name, index_settings)
+	default:
+		gcb.Logger.Error().Msgf("Raw exists response: %s", resp.String())
+		return fmt.Errorf("failed to check if index %s exists, got code [%d]", index_name, resp.StatusCode)
+	}
+}
+
+// indexSchema is the part of an index file that can be applied to an existing index
+type indexSchema struct {
+	Settings struct {
+		Analysis json.RawMessage `json:"analysis"`
+		Mapping  json.RawMessage `json:"mapping"`
+	} `json:"settings"`
+	Mappings json.RawMessage `json:"mappings"`
+}
+
+// updateIndex applies the analyzers, mapping settings, and mappings of the index file to an existing index.
+// Mappings can only gain fields, a field whose type changed needs the reindex described in the README.
+// Existing documents are updated in place when analyzers are added, so they are indexed into the new subfields.
+func updateIndex(ctx context.Context, gcb *app.App, index_name string, index_settings []byte) error {
+	var schema indexSchema
+	if err := json.Unmarshal(index_settings, &schema); err != nil {
+		return fmt.Errorf("failed to parse the schema of index %s: %w", index_name, err)
+	}
+
+	var reindex bool
+	if len(schema.Settings.Analysis) != 0 {
+		hasAnalysis, err := indexHasAnalysis(ctx, gcb, index_name)
+		if err != nil {
+			return err
+		}
+
+		if !hasAnalysis {
+			if err := addAnalysis(ctx, gcb, index_name, schema.Settings.Analysis); err != nil {
+				return err
+			}
+
+			reindex = true
+		}
+	}
+
+	if len(schema.Settings.Mapping) != 0 {
+		body, err := json.Marshal(map[string]any{"index": map[string]any{"mapping": schema.Settings.Mapping}})
+		if err != nil {
+			return fmt.Errorf("failed to marshal the mapping settings of index %s: %w", index_name, err)
+		}
+
+		if err := doIndexRequest(ctx, gcb, opensearchapi.IndicesPutSettingsRequest{
+			Index: []string{index_name},
+			Body:  bytes.NewReader(body),
+		}, "update the mapping settings of", index_name); err != nil {
+			return err
+		}
+	}
+
+	if len(schema.Mappings) != 0 {
+		if err := doIndexRequest(ctx, gcb, opensearchapi.IndicesPutMappingRequest{
+			Index: []string{index_name},
+			Body:  bytes.NewReader(schema.Mappings),
+		}, "update the mappings of", index_name); err != nil {
+			return fmt.Errorf("%w, fields mapped with a different type need a reindex", err)
+		}
+	}
+
+	if reindex {
+		gcb.Logger.Info().Msgf("Updating the documents of index %s for the new analyzers", index_name)
+		if err := doIndexRequest(ctx, gcb, opensearchapi.UpdateByQueryRequest{
+			Index:     []string{index_name},
+			Conflicts: "proceed",
+		}, "update the documents of", index_name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexHasAnalysis reports whether the index has any analysis settings
+func indexHasAnalysis(ctx context.Context, gcb *app.App, index_name string) (bool, error) {
+	settingsRequest := opensearchapi.IndicesGetSettingsRequest{
+		Index: []string{index_name},
+		Name:  []string{"index.analysis*"},
+	}
+
+	resp, err := settingsRequest.Do(ctx, gcb.OSClient)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the settings of index %s: %w", index_name, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			gcb.Logger.Err(err)
+		}
+	}()
+
+	if resp.IsError() {
+		gcb.Logger.Error().Msgf("Raw get settings response: %s", resp.String())
+		return false, fmt.Errorf("failed to get the settings of index %s, got code [%d]", index_name, resp.StatusCode)
+	}
+
+	// keyed by the concrete index name, which differs from index_name when it is an alias
+	var settings map[string]struct {
+		Settings struct {
+			Index struct {
+				Analysis json.RawMessage `json:"analysis"`
+			} `json:"index"`
+		} `json:"settings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return false, fmt.Errorf("failed to decode the settings of index %s: %w", index_name, err)
+	}
+
+	for _, s := range settings {
+		if len(s.Settings.Index.Analysis) != 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// addAnalysis adds the analysis settings to the index. Analysis settings are static,
+// so the index is closed while they are updated and reopened even if the update fails.
+func addAnalysis(ctx context.Context, gcb *app.App, index_name string, analysis json.RawMessage) error {
+	body, err := json.Marshal(map[string]any{"index": map[string]any{"analysis": analysis}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal the analysis settings of index %s: %w", index_name, err)
+	}
+
+	gcb.Logger.Info().Msgf("Closing index %s to add its analyzers", index_name)
+	if err := doIndexRequest(ctx, gcb, opensearchapi.IndicesCloseRequest{Index: []string{index_name}}, "close", index_name); err != nil {
+		return err
+	}
+
+	putErr := doIndexRequest(ctx, gcb, opensearchapi.IndicesPutSettingsRequest{
+		Index: []string{index_name},
+		Body:  bytes.NewReader(body),
+	}, "add the analyzers of", index_name)
+
+	openErr := doIndexRequest(ctx, gcb, opensearchapi.IndicesOpenRequest{Index: []string{index_name}}, "reopen", index_name)
+
+	return errors.Join(putErr, openErr)
+}
+
+// doIndexRequest performs the request against the index, failing on an error response
+func doIndexRequest(ctx context.Context, gcb *app.App, req opensearchapi.Request, action, index_name string) error {
+	resp, err := req.Do(ctx, gcb.OSClient)
+	if err != nil {
+		return fmt.Errorf("failed to %s index %s: %w", action, index_name, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			gcb.Logger.Err(err)
+		}
+	}()
+
+	if resp.IsError() {
+		gcb.Logger.Error().Msgf("Raw response: %s", resp.String())
+		return fmt.Errorf("failed to %s index %s, got code [%d]", action, index_name, resp.StatusCode)
+	}
+
+	gcb.Logger.Debug().Msgf("Debug response: %s", resp.String())
+	return nil
+}
+
+func createIndex(ctx context.Context, gcb *app.App, index_name string, index_settings []byte) error {
 	createIndexRequest := opensearchapi.IndicesCreateRequest{
 		Index: index_name,
 		Body:  bytes.NewReader(index_settings),
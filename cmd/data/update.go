@@ -2,9 +2,11 @@ package data
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,10 +16,14 @@ import (
 	"github.com/wI2L/jsondiff"
 
 	"github.com/gencon_buddy_api/cmd/app"
+	"github.com/gencon_buddy_api/gcbapi"
 	"github.com/gencon_buddy_api/internal/bgg"
 	"github.com/gencon_buddy_api/internal/changelog"
 	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/savedsearch"
 	"github.com/gencon_buddy_api/internal/search"
+	"github.com/gencon_buddy_api/internal/tickets"
+	"github.com/gencon_buddy_api/internal/webhook"
 )
 
 const (
@@ -86,7 +92,7 @@ func update(cmd *cobra.Command, _ []string) error {
 	}
 
 	bggMapping := loadBGGMapping(cmd, gcb.Logger)
-	events, err := eventReader.ReadEvents(cmd.Context(), event.NewHydrateBGG(bggMapping))
+	events, err := eventReader.ReadEvents(cmd.Context(), event.HydrateConventionTime{}, event.NewHydrateBGG(bggMapping))
 	if err != nil {
 		return fmt.Errorf("failed to read events: %w", err)
 	}
@@ -148,13 +154,15 @@ func processChangeLogEvents(ctx context.Context, gcb *app.App, eventList []*even
 		Msg("Creating new change log entry")
 
 	var (
-		count    = 0
-		batchMap = make(map[string]*event.Event)
+		count      = 0
+		batchMap   = make(map[string]*event.Event)
+		eventTypes = make(map[string]string, len(eventList))
 	)
 	fetchList := make([]string, 0, gcb.BatchSize)
 
 	for _, e := range eventList {
 		e.LastChangeLogModification = clEntry.ID
+		eventTypes[e.GameID] = string(e.EventType)
 		fetchList = append(fetchList, e.GameID)
 		batchMap[e.GameID] = e
 		count++
@@ -186,7 +194,7 @@ func processChangeLogEvents(ctx context.Context, gcb *app.App, eventList []*even
 	// definitely hacky, but I don't want to add a waitfor configuration option right now
 	time.Sleep(time.Second * 2)
 
-	if err := processChangeLogDeletions(ctx, gcb, clEntry); err != nil {
+	if err := processChangeLogDeletions(ctx, gcb, clEntry, eventTypes); err != nil {
 		gcb.Logger.Warn().
 			Err(err).
 			Str("change_log_entry_id", clEntry.ID).
@@ -202,6 +210,8 @@ func processChangeLogEvents(ctx context.Context, gcb *app.App, eventList []*even
 		return nil
 	}
 
+	clEntry.CountEventTypes(eventTypes)
+
 	itemErr, err := gcb.ChangeLogRepo.CreateEntries(ctx, clEntry)
 	if err != nil {
 		return fmt.Errorf("failed to call opensearch with a create request: %w", err)
@@ -217,6 +227,20 @@ func processChangeLogEvents(ctx context.Context, gcb *app.App, eventList []*even
 		Int("delete_count", len(clEntry.DeletedEvents)).
 		Msgf("Successfully created change log %s", clEntry.ID)
 
+	if err := processWebhooks(ctx, gcb, clEntry); err != nil {
+		gcb.Logger.Warn().
+			Err(err).
+			Str("change_log_entry_id", clEntry.ID).
+			Msg("failed to send the change log webhooks")
+	}
+
+	if err := processSavedSearches(ctx, gcb, clEntry); err != nil {
+		gcb.Logger.Warn().
+			Err(err).
+			Str("change_log_entry_id", clEntry.ID).
+			Msg("failed to notify saved searches of the changed events")
+	}
+
 	return nil
 }
 
@@ -276,6 +300,7 @@ func processChangeLogBatch(ctx context.Context, gcb *app.App, clEntry *changelog
 		if len(p) > 0 {
 			// Only include the entry as an update, if some field changed
 			clEntry.UpdatedEvents = append(clEntry.UpdatedEvents, id)
+			clEntry.Changes = append(clEntry.Changes, changelog.NewEventChange(id, p))
 		}
 
 		// update every event to always set the lastChangeLogModification
@@ -283,6 +308,13 @@ func processChangeLogBatch(ctx context.Context, gcb *app.App, clEntry *changelog
 		updateEvents = append(updateEvents, updateEvent)
 	}
 
+	if err := recordTicketSnapshots(ctx, gcb, clEntry, writeEvents, fetchedEvents.Found, eventBatch); err != nil {
+		gcb.Logger.Warn().
+			Err(err).
+			Str("change_log_entry_id", clEntry.ID).
+			Msg("failed to record the ticket snapshots of the batch")
+	}
+
 	if len(updateEvents) > 0 {
 		updateErrs, reqErr := gcb.EventRepo.UpdateEvents(ctx, updateEvents)
 		if reqErr != nil {
@@ -300,7 +332,63 @@ func processChangeLogBatch(ctx context.Context, gcb *app.App, clEntry *changelog
 	return nil
 }
 
-func processChangeLogDeletions(ctx context.Context, gcb *app.App, clEntry *changelog.Entry) error {
+// recordTicketSnapshots snapshots the ticket counts of new events and of existing events whose available tickets
+// changed, or that have no history yet. Every existing event's TicketsSoldPerHour is recalculated from its
+// history, and keeps its previous value if the history cannot be read.
+func recordTicketSnapshots(ctx context.Context, gcb *app.App, clEntry *changelog.Entry, created []*event.Event, existing, eventBatch map[string]*event.Event) error {
+	now, err := time.Parse(time.RFC3339, clEntry.Date)
+	if err != nil {
+		return fmt.Errorf("invalid change log date %s: %w", clEntry.Date, err)
+	}
+
+	snapshots := make([]*tickets.Snapshot, 0, len(created))
+	for _, e := range created {
+		snapshots = append(snapshots, tickets.NewSnapshot(e.GameID, clEntry.ID, clEntry.Date, e.TicketsAvailable, e.TotalTickets))
+	}
+
+	ids := make([]string, 0, len(existing))
+	for id := range existing {
+		if _, ok := eventBatch[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+
+	baselines, baselineErr := gcb.TicketRepo.Baselines(ctx, ids, now)
+
+	for _, id := range ids {
+		previous, updated := existing[id], eventBatch[id]
+
+		if baselineErr != nil {
+			updated.TicketsSoldPerHour = previous.TicketsSoldPerHour
+		} else {
+			updated.TicketsSoldPerHour = tickets.SoldPerHour(baselines[id], updated.TicketsAvailable, now)
+		}
+
+		_, hasHistory := baselines[id]
+		if updated.TicketsAvailable != previous.TicketsAvailable || (baselineErr == nil && !hasHistory) {
+			snapshots = append(snapshots, tickets.NewSnapshot(id, clEntry.ID, clEntry.Date, updated.TicketsAvailable, previous.TotalTickets))
+		}
+	}
+
+	writeErrs, err := gcb.TicketRepo.WriteSnapshots(ctx, snapshots...)
+	if err != nil {
+		return errors.Join(baselineErr, fmt.Errorf("failed to write the ticket snapshots: %w", err))
+	}
+
+	if len(writeErrs) != 0 {
+		return errors.Join(baselineErr, fmt.Errorf("failed to write some ticket snapshots: %w", errors.Join(writeErrs...)))
+	}
+
+	if baselineErr != nil {
+		return fmt.Errorf("failed to fetch the ticket history, sell-through velocity was not updated: %w", baselineErr)
+	}
+
+	return nil
+}
+
+// processChangeLogDeletions marks the events missing from the change log as deleted,
+// adding the event type of each deleted event to eventTypes.
+func processChangeLogDeletions(ctx context.Context, gcb *app.App, clEntry *changelog.Entry, eventTypes map[string]string) error {
 	changeLogSearchTerm, err := event.NewSearchField(string(event.LastChangeLogModification), clEntry.ID)
 	if err != nil {
 		return fmt.Errorf("could not build search term for change log id: %w", err)
@@ -335,9 +423,11 @@ func processChangeLogDeletions(ctx context.Context, gcb *app.App, clEntry *chang
 			e.LastChangeLogModification = clEntry.ID
 			deleteEvents = append(deleteEvents, e)
 			clEntry.DeletedEvents = append(clEntry.DeletedEvents, e.GameID)
+			eventTypes[e.GameID] = string(e.EventType)
 		}
 
-		deletedEventsSearchRequest.Page += 1
+		// page with search_after so deletions are not capped by the result window
+		deletedEventsSearchRequest.SearchAfter = results.SearchAfter
 		results, err = gcb.EventRepo.Search(Cmd.Context(), deletedEventsSearchRequest)
 	}
 
@@ -352,6 +442,7 @@ func processChangeLogDeletions(ctx context.Context, gcb *app.App, clEntry *chang
 		e.LastChangeLogModification = clEntry.ID
 		deleteEvents = append(deleteEvents, e)
 		clEntry.DeletedEvents = append(clEntry.DeletedEvents, e.GameID)
+		eventTypes[e.GameID] = string(e.EventType)
 	}
 
 	if len(deleteEvents) == 0 {
@@ -374,3 +465,150 @@ func processChangeLogDeletions(ctx context.Context, gcb *app.App, clEntry *chang
 
 	return nil
 }
+
+// savedSearchBatchSize is the number of changed events each saved search is evaluated against at once
+const savedSearchBatchSize = 1000
+
+// processSavedSearches evaluates every saved search against the created and updated events
+// of the change log, and records a notification for each saved search with matches.
+func processSavedSearches(ctx context.Context, gcb *app.App, clEntry *changelog.Entry) error {
+	changed := make([]string, 0, len(clEntry.CreatedEvents)+len(clEntry.UpdatedEvents))
+	changed = append(changed, clEntry.CreatedEvents...)
+	changed = append(changed, clEntry.UpdatedEvents...)
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	created := make(map[string]struct{}, len(clEntry.CreatedEvents))
+	for _, id := range clEntry.CreatedEvents {
+		created[id] = struct{}{}
+	}
+
+	savedSearches, err := gcb.SavedSearchRepo.All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the saved searches: %w", err)
+	}
+
+	var notifications []*savedsearch.Notification
+	for _, s := range savedSearches {
+		notification := savedsearch.NewNotification(s.ID, clEntry.ID)
+
+		for batch := range slices.Chunk(changed, savedSearchBatchSize) {
+			searchReq, err := s.SearchRequest(batch, len(batch))
+			if err != nil {
+				gcb.Logger.Warn().Err(err).
+					Str("saved_search_id", s.ID).
+					Msg("failed to build the saved search request, skipping")
+				break
+			}
+
+			results, err := gcb.EventRepo.Search(ctx, searchReq)
+			if err != nil {
+				gcb.Logger.Warn().Err(err).
+					Str("saved_search_id", s.ID).
+					Str("change_log_entry_id", clEntry.ID).
+					Msg("failed to search for the saved search matches")
+				continue
+			}
+
+			for _, e := range results.Events {
+				if _, ok := created[e.GameID]; ok {
+					notification.CreatedEvents = append(notification.CreatedEvents, e.GameID)
+				} else {
+					notification.UpdatedEvents = append(notification.UpdatedEvents, e.GameID)
+				}
+			}
+		}
+
+		if len(notification.CreatedEvents) != 0 || len(notification.UpdatedEvents) != 0 {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	writeErrs, err := gcb.SavedSearchRepo.CreateNotifications(ctx, notifications...)
+	if err != nil {
+		return fmt.Errorf("failed to write the saved search notifications: %w", err)
+	}
+
+	if len(writeErrs) != 0 {
+		return fmt.Errorf("failed to write some saved search notifications: %w", errors.Join(writeErrs...))
+	}
+
+	gcb.Logger.Info().
+		Int("saved_search_count", len(savedSearches)).
+		Int("notification_count", len(notifications)).
+		Msgf("Notified saved searches of change log %s", clEntry.ID)
+
+	return nil
+}
+
+// processWebhooks posts the new change log entry to every registered webhook endpoint.
+// The hydrated events are only fetched if an endpoint asks for them. Sending is bounded by the
+// dispatcher timeout, so a dead endpoint cannot hold up the update, and failed deliveries are left
+// for the webhook replay command.
+func processWebhooks(ctx context.Context, gcb *app.App, clEntry *changelog.Entry) error {
+	var (
+		summary = gcbapi.ChangeLogWebhook{
+			Event:   webhook.ChangeLogCreated,
+			Summary: clEntry.Summarize(),
+		}
+		summaryBody, hydratedBody []byte
+	)
+
+	payload := func(endpoint *webhook.Endpoint) ([]byte, error) {
+		if !endpoint.IncludeEvents {
+			if summaryBody == nil {
+				body, err := json.Marshal(summary)
+				if err != nil {
+					return nil, err
+				}
+
+				summaryBody = body
+			}
+
+			return summaryBody, nil
+		}
+
+		if hydratedBody == nil {
+			hydrator := changelog.NewEntryHydrator(&gcb.Logger, gcb.ChangeLogRepo, gcb.EventRepo)
+			entry, err := hydrator.FetchEntry(ctx, clEntry.ID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hydrate change log %s: %w", clEntry.ID, err)
+			}
+
+			hydrated := summary
+			hydrated.Entry = &entry
+
+			body, err := json.Marshal(hydrated)
+			if err != nil {
+				return nil, err
+			}
+
+			hydratedBody = body
+		}
+
+		return hydratedBody, nil
+	}
+
+	dispatcher := webhook.NewDispatcher(&gcb.Logger, gcb.WebhookRepo, webhook.NewSender())
+	deliveries, err := dispatcher.Dispatch(ctx, webhook.ChangeLogCreated, clEntry.ID, payload)
+
+	failed := 0
+	for _, d := range deliveries {
+		if d.Status == webhook.Failed {
+			failed++
+		}
+	}
+
+	gcb.Logger.Info().
+		Int("delivery_count", len(deliveries)).
+		Int("failed_count", failed).
+		Msgf("Sent webhooks for change log %s", clEntry.ID)
+
+	return err
+}
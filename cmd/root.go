@@ -12,16 +12,22 @@ import (
 	"github.com/gencon_buddy_api/cmd/api"
 	"github.com/gencon_buddy_api/cmd/app"
 	"github.com/gencon_buddy_api/cmd/data"
+	"github.com/gencon_buddy_api/cmd/webhook"
 )
 
 const (
-	flagVerbosity        = "verbosity"
-	flagBatchSize        = "batch_size"
-	flagOSAddress        = "os_address"
-	flagOSUsername       = "os_username"
-	flagOSPassword       = "os_password"
-	flagOSEventIndex     = "event_index"
-	flagOSChangeLogIndex = "change_log_index"
+	flagVerbosity           = "verbosity"
+	flagBatchSize           = "batch_size"
+	flagOSAddress           = "os_address"
+	flagOSUsername          = "os_username"
+	flagOSPassword          = "os_password"
+	flagOSEventIndex        = "event_index"
+	flagOSChangeLogIndex    = "change_log_index"
+	flagOSSavedSearchIndex  = "saved_search_index"
+	flagOSNotificationIndex = "notification_index"
+	flagOSTicketIndex       = "ticket_snapshot_index"
+	flagOSWebhookIndex      = "webhook_index"
+	flagOSWebhookDelivery   = "webhook_delivery_index"
 )
 
 var (
@@ -40,12 +46,17 @@ var (
 			}
 
 			config := app.AppConfig{
-				OSAddress:      viper.GetString(flagOSAddress),
-				OSUsername:     viper.GetString(flagOSUsername),
-				OSPassword:     viper.GetString(flagOSPassword),
-				EventIndex:     viper.GetString(flagOSEventIndex),
-				ChangeLogIndex: viper.GetString(flagOSChangeLogIndex),
-				BatchSize:      viper.GetInt(flagBatchSize),
+				OSAddress:         viper.GetString(flagOSAddress),
+				OSUsername:        viper.GetString(flagOSUsername),
+				OSPassword:        viper.GetString(flagOSPassword),
+				EventIndex:        viper.GetString(flagOSEventIndex),
+				ChangeLogIndex:    viper.GetString(flagOSChangeLogIndex),
+				SavedSearchIndex:  viper.GetString(flagOSSavedSearchIndex),
+				NotificationIndex: viper.GetString(flagOSNotificationIndex),
+				TicketIndex:       viper.GetString(flagOSTicketIndex),
+				WebhookIndex:      viper.GetString(flagOSWebhookIndex),
+				DeliveryIndex:     viper.GetString(flagOSWebhookDelivery),
+				BatchSize:         viper.GetInt(flagBatchSize),
 			}
 
 			logger := zerolog.New(
@@ -85,11 +96,27 @@ func init() {
 	gcbRootCmd.PersistentFlags().String(flagOSChangeLogIndex, "change_log_index", "Root index name. This value is used as the primary change log index. Defaults to 'change_log_index'")
 	viper.BindPFlag("CHANGE_LOG_INDEX", gcbRootCmd.PersistentFlags().Lookup(flagOSChangeLogIndex))
 
+	gcbRootCmd.PersistentFlags().String(flagOSSavedSearchIndex, "saved_search_index", "Index name for saved searches. Defaults to 'saved_search_index'")
+	viper.BindPFlag("SAVED_SEARCH_INDEX", gcbRootCmd.PersistentFlags().Lookup(flagOSSavedSearchIndex))
+
+	gcbRootCmd.PersistentFlags().String(flagOSNotificationIndex, "saved_search_notification_index", "Index name for saved search notifications. Defaults to 'saved_search_notification_index'")
+	viper.BindPFlag("NOTIFICATION_INDEX", gcbRootCmd.PersistentFlags().Lookup(flagOSNotificationIndex))
+
+	gcbRootCmd.PersistentFlags().String(flagOSTicketIndex, "ticket_snapshot_index", "Index name for the ticket availability snapshots. Defaults to 'ticket_snapshot_index'")
+	viper.BindPFlag("TICKET_SNAPSHOT_INDEX", gcbRootCmd.PersistentFlags().Lookup(flagOSTicketIndex))
+
+	gcbRootCmd.PersistentFlags().String(flagOSWebhookIndex, "webhook_endpoint_index", "Index name for webhook endpoints. Defaults to 'webhook_endpoint_index'")
+	viper.BindPFlag("WEBHOOK_INDEX", gcbRootCmd.PersistentFlags().Lookup(flagOSWebhookIndex))
+
+	gcbRootCmd.PersistentFlags().String(flagOSWebhookDelivery, "webhook_delivery_index", "Index name for the webhook delivery log. Defaults to 'webhook_delivery_index'")
+	viper.BindPFlag("WEBHOOK_DELIVERY_INDEX", gcbRootCmd.PersistentFlags().Lookup(flagOSWebhookDelivery))
+
 	gcbRootCmd.PersistentFlags().Int(flagBatchSize, 100, "Size of batches/pages for interactin with opensearch.")
 	viper.BindPFlag("BATCH_SIZE", gcbRootCmd.PersistentFlags().Lookup(flagBatchSize))
 
 	gcbRootCmd.AddCommand(api.ServiceCmd)
 	gcbRootCmd.AddCommand(data.Cmd)
+	gcbRootCmd.AddCommand(webhook.Cmd)
 }
 
 func Execute() {
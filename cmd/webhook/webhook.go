@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gencon_buddy_api/cmd/app"
+	"github.com/gencon_buddy_api/internal/webhook"
+)
+
+const (
+	flagSecret        = "secret"
+	flagIncludeEvents = "include-events"
+	flagLimit         = "limit"
+)
+
+var (
+	Cmd = &cobra.Command{
+		Use:   "webhook",
+		Short: "Manage the webhook endpoints notified of new change log entries",
+	}
+
+	addCmd = &cobra.Command{
+		Use:   "add <url>",
+		Short: "Register a webhook endpoint",
+		Long:  "Registers an endpoint to receive a signed POST for every new change log entry. A secret is generated when --secret is not set, and is printed once.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  add,
+	}
+
+	listCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the registered webhook endpoints",
+		Args:  cobra.NoArgs,
+		RunE:  list,
+	}
+
+	removeCmd = &cobra.Command{
+		Use:   "remove <endpoint id>...",
+		Short: "Remove webhook endpoints",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  remove,
+	}
+
+	replayCmd = &cobra.Command{
+		Use:   "replay [delivery id]...",
+		Short: "Resend failed webhook deliveries",
+		Long:  "Resends the given deliveries, or the oldest failed deliveries when no ids are given, with their original payload.",
+		RunE:  replay,
+	}
+)
+
+func init() {
+	addCmd.Flags().String(flagSecret, "", "the secret used to sign deliveries, a random one is generated if not set")
+	addCmd.Flags().Bool(flagIncludeEvents, false, "include the hydrated events in the payload, rather than only the change log summary")
+
+	replayCmd.Flags().Int(flagLimit, 100, "the max number of failed deliveries to replay when no ids are given")
+
+	Cmd.AddCommand(addCmd)
+	Cmd.AddCommand(listCmd)
+	Cmd.AddCommand(removeCmd)
+	Cmd.AddCommand(replayCmd)
+}
+
+func add(cmd *cobra.Command, args []string) error {
+	gcb := app.GetAppFromContext(cmd.Context())
+	if gcb == nil {
+		return fmt.Errorf("couldn't initialize gcb app context")
+	}
+
+	secret, err := cmd.Flags().GetString(flagSecret)
+	if err != nil {
+		return fmt.Errorf("failed to read %s flag: %w", flagSecret, err)
+	}
+
+	includeEvents, err := cmd.Flags().GetBool(flagIncludeEvents)
+	if err != nil {
+		return fmt.Errorf("failed to read %s flag: %w", flagIncludeEvents, err)
+	}
+
+	endpoint, err := webhook.NewEndpoint(args[0], secret, includeEvents)
+	if err != nil {
+		return err
+	}
+
+	if err := gcb.WebhookRepo.CreateEndpoint(cmd.Context(), endpoint); err != nil {
+		return fmt.Errorf("failed to create the webhook endpoint: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "id: %s\nurl: %s\nsecret: %s\n", endpoint.ID, endpoint.URL, endpoint.Secret)
+
+	return nil
+}
+
+func list(cmd *cobra.Command, _ []string) error {
+	gcb := app.GetAppFromContext(cmd.Context())
+	if gcb == nil {
+		return fmt.Errorf("couldn't initialize gcb app context")
+	}
+
+	endpoints, err := gcb.WebhookRepo.Endpoints(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list the webhook endpoints: %w", err)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tURL\tINCLUDE EVENTS\tDATE")
+	for _, e := range endpoints {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", e.ID, e.URL, e.IncludeEvents, e.Date)
+	}
+
+	return w.Flush()
+}
+
+func remove(cmd *cobra.Command, args []string) error {
+	gcb := app.GetAppFromContext(cmd.Context())
+	if gcb == nil {
+		return fmt.Errorf("couldn't initialize gcb app context")
+	}
+
+	for _, id := range args {
+		found, err := gcb.WebhookRepo.DeleteEndpoint(cmd.Context(), id)
+		if err != nil {
+			return fmt.Errorf("failed to remove webhook endpoint %s: %w", id, err)
+		}
+
+		if !found {
+			gcb.Logger.Warn().Str("endpoint_id", id).Msg("webhook endpoint does not exist")
+			continue
+		}
+
+		gcb.Logger.Info().Str("endpoint_id", id).Msg("Removed webhook endpoint")
+	}
+
+	return nil
+}
+
+func replay(cmd *cobra.Command, args []string) error {
+	gcb := app.GetAppFromContext(cmd.Context())
+	if gcb == nil {
+		return fmt.Errorf("couldn't initialize gcb app context")
+	}
+
+	var (
+		deliveries []*webhook.Delivery
+		err        error
+	)
+
+	if len(args) > 0 {
+		deliveries, err = gcb.WebhookRepo.FetchDeliveries(cmd.Context(), args...)
+	} else {
+		limit, flagErr := cmd.Flags().GetInt(flagLimit)
+		if flagErr != nil {
+			return fmt.Errorf("failed to read %s flag: %w", flagLimit, flagErr)
+		}
+
+		deliveries, err = gcb.WebhookRepo.FailedDeliveries(cmd.Context(), limit)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch the webhook deliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		gcb.Logger.Info().Msg("No webhook deliveries to replay")
+		return nil
+	}
+
+	dispatcher := webhook.NewDispatcher(&gcb.Logger, gcb.WebhookRepo, webhook.NewSender())
+	replayErr := dispatcher.Replay(cmd.Context(), deliveries...)
+
+	failed := 0
+	for _, d := range deliveries {
+		if d.Status == webhook.Failed {
+			failed++
+		}
+	}
+
+	gcb.Logger.Info().
+		Int("delivery_count", len(deliveries)).
+		Int("failed_count", failed).
+		Msg("Replayed webhook deliveries")
+
+	return replayErr
+}
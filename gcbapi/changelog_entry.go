@@ -7,12 +7,26 @@ type ChangeLogSummary struct {
 	UpdatedCount int    `json:"updatedCount"`
 	DeletedCount int    `json:"deletedCount"`
 	CreatedCount int    `json:"createdCount"`
+	// EventTypes breaks the counts down by event type.
+	// Change logs from before the breakdown was recorded have none.
+	EventTypes []EventTypeCounts `json:"eventTypes"`
+}
+
+// EventTypeCounts is how many events of an event type were changed.
+type EventTypeCounts struct {
+	EventType    string `json:"eventType"`
+	UpdatedCount int    `json:"updatedCount"`
+	DeletedCount int    `json:"deletedCount"`
+	CreatedCount int    `json:"createdCount"`
 }
 
 // ListChangeLogsResponse lists [ChangeLogSummay]s.
 type ListChangeLogsResponse struct {
 	Error   string             `json:"error,omitempty"`
 	Entries []ChangeLogSummary `json:"entries,omitempty"`
+	// NextCursor continues the listing with the next page of older change logs.
+	// It is left out on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // ChangeLogEntry includes fully hydrated events.
@@ -22,6 +36,16 @@ type ChangeLogEntry struct {
 	UpdatedEvents []Event `json:"updatedEvents"`
 	DeletedEvents []Event `json:"deletedEvents"`
 	CreatedEvents []Event `json:"createdEvents"`
+	// Changes lists the changed fields of each updated event, keyed by game id
+	Changes map[string][]FieldChange `json:"changes"`
+}
+
+// FieldChange is the old and new value of a field of an updated event.
+// Old is null when the field was added and New is null when it was removed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
 }
 
 // FetchChangeLogResponse is the api response for the fetch actionz.
@@ -29,3 +53,26 @@ type FetchChangeLogResponse struct {
 	Error string         `json:"error,omitempty"`
 	Entry ChangeLogEntry `json:"entry,omitempty"`
 }
+
+// EventHistoryEntry is what a single change log did to an event.
+type EventHistoryEntry struct {
+	ChangeLogID string `json:"changeLogId"`
+	Date        string `json:"date"`
+	// Action is one of created, updated, or deleted
+	Action string `json:"action"`
+	// Changes are the changed fields of an update.
+	// Change logs from before changes were recorded have none.
+	Changes []FieldChange `json:"changes"`
+}
+
+// EventHistory is every change log that changed an event, oldest first.
+type EventHistory struct {
+	GameID  string              `json:"gameId"`
+	Entries []EventHistoryEntry `json:"entries"`
+}
+
+// EventHistoryResponse is the response for the event history endpoint.
+type EventHistoryResponse struct {
+	History *EventHistory `json:"history,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
@@ -9,6 +9,14 @@ type Event struct {
 	ID         string          `json:"id"`
 	Type       string          `json:"type"`
 	Attributes EventAttributes `json:"attributes"`
+	Meta       *EventMeta      `json:"meta,omitempty"`
+}
+
+// EventMeta is the JSONAPI spec meta for a single Event
+type EventMeta struct {
+	// Highlights maps field names to their matched fragments, with matches wrapped in <em> tags.
+	// The event text in each fragment is HTML-escaped, so the <em> tags are the only markup.
+	Highlights map[string][]string `json:"highlights,omitempty"`
 }
 
 // EventAttributes wrap the JSONAPI spec attributes for the Event
@@ -35,6 +43,9 @@ type EventAttributes struct {
 	StartDateTime            time.Time `json:"startDateTime"`
 	Duration                 float64   `json:"duration"`
 	EndDateTime              time.Time `json:"endDateTime"`
+	Day                      string    `json:"day"`       // weekday the event starts on in Indy time, e.g. thu
+	StartHour                int64     `json:"startHour"` // hour the event starts in Indy time
+	EndHour                  int64     `json:"endHour"`   // hour the event ends in Indy time, past 23 when it ends after midnight
 	GMNames                  string    `json:"gmNames"`
 	Website                  string    `json:"website"`
 	Email                    string    `json:"email"`
@@ -50,22 +61,91 @@ type EventAttributes struct {
 	SpecialCategory          string    `json:"specialCategory"`
 	TicketsAvailable         int64     `json:"ticketsAvailable"`
 	TotalTickets             int64     `json:"totalTickets"`
+	TicketsSoldPerHour       float64   `json:"ticketsSoldPerHour"`
 	LastModified             time.Time `json:"lastModified"`
 	AlsoRuns                 time.Time `json:"alsoRuns"`
 	Prize                    string    `json:"prize"`
 	RulesComplexity          string    `json:"rulesComplexity"`
 	OriginalOrder            int64     `json:"originalOrder"`
+	Deleted                  bool      `json:"deleted"`
 }
 
 // EventSearchResponse respects JSON:API specification for a JSON
 // document response on the event search endpoint
 type EventSearchResponse struct {
-	Links Links   `json:"links"`
-	Data  []Event `json:"data,omitempty"`
-	Meta  struct {
-		Total int64 `json:"total"`
-	} `json:"meta"`
-	Error *Error `json:"error,omitempty"`
+	Links Links      `json:"links"`
+	Data  []Event    `json:"data,omitempty"`
+	Meta  SearchMeta `json:"meta"`
+	Error *Error     `json:"error,omitempty"`
+}
+
+// EventSearchQuery is the body of a structured event search.
+// Every part is optional, an empty query lists the first page of events.
+type EventSearchQuery struct {
+	// Where filters the events
+	Where *SearchGroup `json:"where,omitempty"`
+	// Sort is applied in order, ties fall to the next sort
+	Sort []SearchSort `json:"sort,omitempty"`
+	// Limit is the number of events to return. Default is 100.
+	Limit *int `json:"limit,omitempty"`
+	// Page is the page of events to return, based on the limit. Cannot be combined with Cursor.
+	Page *int `json:"page,omitempty"`
+	// Cursor continues a previous search from its meta.nextCursor token, keeping its sort.
+	// The where and excludeConflictsWith filters must match the search the cursor came from.
+	Cursor string `json:"cursor,omitempty"`
+	// Facets are the fields to return value counts for in meta.facets
+	Facets []string `json:"facets,omitempty"`
+	// Highlight returns the matching fragments of text fields in each event's meta.highlights
+	Highlight bool `json:"highlight,omitempty"`
+	// ExcludeConflictsWith are game ids of events, ie a schedule, whose times the events cannot overlap
+	ExcludeConflictsWith []string `json:"excludeConflictsWith,omitempty"`
+	// ConflictBuffer is the minutes kept free before and after each ExcludeConflictsWith event
+	ConflictBuffer *int `json:"conflictBuffer,omitempty"`
+}
+
+// SearchSort sorts the search results by a field.
+type SearchSort struct {
+	Field string `json:"field"`
+	// Dir is asc or desc
+	Dir string `json:"dir"`
+}
+
+// SearchGroup combines its conditions and nested groups.
+type SearchGroup struct {
+	// Op is and (the default) to match all of them, or to match any of them,
+	// or not to match none of them.
+	Op         string            `json:"op,omitempty"`
+	Conditions []SearchCondition `json:"conditions,omitempty"`
+	Groups     []SearchGroup     `json:"groups,omitempty"`
+}
+
+// SearchCondition compares a single field.
+type SearchCondition struct {
+	Field string `json:"field"`
+	// Op is one of
+	//   - eq (the default) to match Value, formatted like the field's search query param
+	//   - in to match any of Values
+	//   - lt, lte, gt, or gte to compare a number or date field to Value
+	//   - between to match a number or date field from Values[0] to Values[1], inclusive
+	//   - phrase to match the words of Value in order on a text field
+	Op string `json:"op,omitempty"`
+	// Value is a string, number, or boolean
+	Value any `json:"value,omitempty"`
+	// Values are strings, numbers, or booleans
+	Values []any `json:"values,omitempty"`
+}
+
+// SearchMeta is the JSON:API meta object for the event search response.
+// Page is omitted when the search was continued with a cursor.
+// NextCursor is an opaque token that continues the same search after the last returned event.
+type SearchMeta struct {
+	Total      int64  `json:"total"`
+	Page       *int   `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	TotalPages int64  `json:"totalPages"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	// Facets maps each requested facet field to its value counts
+	Facets map[string][]KeywordFacet `json:"facets,omitempty"`
 }
 
 // Pagination implements the JSON:API [Pagination Object](https://jsonapi.org/format/#document-links)
@@ -88,10 +168,17 @@ type Error struct {
 	Detail string `json:"detail"`
 }
 
-// EventFetchResponse is moving away from the JSON:API spec
+// EventFetchRequest is the body for fetching a batch of events by their game ids.
+type EventFetchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// EventFetchResponse is moving away from the JSON:API spec.
+// Missing lists the requested ids that could not be found.
 type EventFetchResponse struct {
-	Events []Event `json:"events,omitempty"`
-	Error  string  `json:"error,omitempty"`
+	Events  []Event  `json:"events,omitempty"`
+	Missing []string `json:"missing,omitempty"`
+	Error   string   `json:"error,omitempty"`
 }
 
 // KeywordFacet is a single keyword field value with its event count.
@@ -105,3 +192,41 @@ type KeywordFacetsResponse struct {
 	Values []KeywordFacet `json:"values,omitempty"`
 	Error  string         `json:"error,omitempty"`
 }
+
+// HistogramBucket is a single bucket of a numeric or date distribution with its event count.
+// Key is the lower bound of the bucket, date keys are epoch milliseconds with KeyAsString
+// set to the bucket start in America/Indianapolis time. Range buckets set From and To.
+type HistogramBucket struct {
+	Key         float64  `json:"key"`
+	KeyAsString string   `json:"keyAsString,omitempty"`
+	From        *float64 `json:"from,omitempty"`
+	To          *float64 `json:"to,omitempty"`
+	Count       int64    `json:"count"`
+}
+
+// HistogramFacetsResponse is the response for histogram facet endpoints.
+type HistogramFacetsResponse struct {
+	Interval string            `json:"interval,omitempty"`
+	Buckets  []HistogramBucket `json:"buckets,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// Suggestion is a single search-as-you-type completion.
+// Type is the event field the value completes, ie title, gameSystem, group, or gmNames.
+type Suggestion struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+	Count int64  `json:"count"`
+}
+
+// SimilarEventsResponse is the response for the similar events endpoint.
+type SimilarEventsResponse struct {
+	Events []Event `json:"events"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// SuggestResponse is the response for the suggest endpoint.
+type SuggestResponse struct {
+	Suggestions []Suggestion `json:"suggestions"`
+	Error       string       `json:"error,omitempty"`
+}
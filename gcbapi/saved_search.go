@@ -0,0 +1,37 @@
+package gcbapi
+
+// SavedSearch is an event search saved under an ID.
+type SavedSearch struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// Query is the query string of an event search, such as "gameSystem=Pathfinder&cost=[,4]"
+	Query string `json:"query"`
+	Date  string `json:"date"`
+}
+
+// CreateSavedSearchRequest is the body for saving a search.
+type CreateSavedSearchRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// SavedSearchResponse is the api response for creating or fetching a saved search.
+type SavedSearchResponse struct {
+	Error       string       `json:"error,omitempty"`
+	SavedSearch *SavedSearch `json:"savedSearch,omitempty"`
+}
+
+// SavedSearchNotification lists the events from a single change log that matched a saved search.
+type SavedSearchNotification struct {
+	ID            string   `json:"id"`
+	ChangeLogID   string   `json:"changeLogId"`
+	Date          string   `json:"date"`
+	CreatedEvents []string `json:"createdEvents"`
+	UpdatedEvents []string `json:"updatedEvents"`
+}
+
+// ListSavedSearchNotificationsResponse lists a saved search's notifications, newest first.
+type ListSavedSearchNotificationsResponse struct {
+	Error         string                    `json:"error,omitempty"`
+	Notifications []SavedSearchNotification `json:"notifications,omitempty"`
+}
@@ -0,0 +1,43 @@
+package gcbapi
+
+// ScheduleAnalyzeRequest is the body for analyzing a schedule of events.
+type ScheduleAnalyzeRequest struct {
+	GameIDs []string `json:"gameIds"`
+	// TransitionMinutes is how soon an event must start after another ends, in a different location,
+	// to be flagged as a tight transition. Defaults to 15.
+	TransitionMinutes *int `json:"transitionMinutes,omitempty"`
+}
+
+// ScheduleOverlap is a pair of scheduled events whose times overlap.
+type ScheduleOverlap struct {
+	FirstGameID    string `json:"firstGameId"`
+	SecondGameID   string `json:"secondGameId"`
+	OverlapMinutes int    `json:"overlapMinutes"`
+}
+
+// ScheduleTransition is a pair of back to back events in different locations.
+type ScheduleTransition struct {
+	FromGameID   string `json:"fromGameId"`
+	ToGameID     string `json:"toGameId"`
+	FromLocation string `json:"fromLocation"`
+	ToLocation   string `json:"toLocation"`
+	GapMinutes   int    `json:"gapMinutes"`
+}
+
+// ScheduleUnavailableEvent is a scheduled event that cannot be attended.
+type ScheduleUnavailableEvent struct {
+	GameID string `json:"gameId"`
+	// Reason is either deleted or soldOut
+	Reason string `json:"reason"`
+}
+
+// ScheduleAnalyzeResponse lists the conflicts found in a schedule.
+type ScheduleAnalyzeResponse struct {
+	Events      []Event                    `json:"events"`
+	Overlaps    []ScheduleOverlap          `json:"overlaps"`
+	Transitions []ScheduleTransition       `json:"transitions"`
+	Unavailable []ScheduleUnavailableEvent `json:"unavailable"`
+	TotalCost   float64                    `json:"totalCost"`
+	Missing     []string                   `json:"missing,omitempty"`
+	Error       string                     `json:"error,omitempty"`
+}
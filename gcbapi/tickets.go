@@ -0,0 +1,37 @@
+package gcbapi
+
+import "time"
+
+// TicketSnapshot is the ticket counts of an event as of a change log.
+// The counts hold until the next snapshot.
+type TicketSnapshot struct {
+	Date             string `json:"date"`
+	ChangeLogID      string `json:"changeLogId"`
+	TicketsAvailable int64  `json:"ticketsAvailable"`
+	TotalTickets     int64  `json:"totalTickets"`
+}
+
+// TicketMetrics are derived from the ticket history of an event.
+type TicketMetrics struct {
+	Sold        int64   `json:"sold"`
+	PercentSold float64 `json:"percentSold"`
+	// SoldPerHour is the sell-through velocity over the last 24 hours
+	SoldPerHour float64 `json:"soldPerHour"`
+	// ProjectedSellOut is left out when the event is not selling or is already sold out
+	ProjectedSellOut *time.Time `json:"projectedSellOut,omitempty"`
+}
+
+// TicketHistory is the current ticket counts of an event, along with how they got there.
+type TicketHistory struct {
+	GameID           string           `json:"gameId"`
+	TicketsAvailable int64            `json:"ticketsAvailable"`
+	TotalTickets     int64            `json:"totalTickets"`
+	Metrics          TicketMetrics    `json:"metrics"`
+	Snapshots        []TicketSnapshot `json:"snapshots"`
+}
+
+// TicketHistoryResponse is the response for the ticket history endpoint.
+type TicketHistoryResponse struct {
+	History *TicketHistory `json:"history,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
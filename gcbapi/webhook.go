@@ -0,0 +1,9 @@
+package gcbapi
+
+// ChangeLogWebhook is the body posted to webhook endpoints when a change log entry is created.
+type ChangeLogWebhook struct {
+	Event   string           `json:"event"`
+	Summary ChangeLogSummary `json:"summary"`
+	// Entry holds the hydrated events, and is only sent to endpoints registered to include them
+	Entry *ChangeLogEntry `json:"entry,omitempty"`
+}
@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful/v3"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/changelog"
+	"github.com/gencon_buddy_api/internal/event"
 )
 
 // ChangeLogHandler is the API handler for all /api/changelog/* endpoints
@@ -17,14 +22,18 @@ type ChangeLogHandler struct {
 	logger  *zerolog.Logger
 	ws      *restful.WebService
 	manager ChangeLogManager
+	stream  *ChangeLogStream
+	cursors *event.CursorSigner
 }
 
 // NewChangeLogHandler instantiates a [ChangeLogHandler]
-func NewChangeLogHandler(logger *zerolog.Logger, manager ChangeLogManager) *ChangeLogHandler {
+func NewChangeLogHandler(logger *zerolog.Logger, manager ChangeLogManager, stream *ChangeLogStream, cursors *event.CursorSigner) *ChangeLogHandler {
 	return &ChangeLogHandler{
 		logger:  logger,
 		ws:      new(restful.WebService),
 		manager: manager,
+		stream:  stream,
+		cursors: cursors,
 	}
 }
 
@@ -43,16 +52,35 @@ func (c *ChangeLogHandler) Register() error {
 	c.ws.Produces(restful.MIME_JSON)
 
 	c.ws.Route(c.ws.GET("/list").To(c.ListChangeLogs).
-		Doc("List the Change Logs as summaries. Only the event modification counts will be shown.").
+		Doc("List the Change Logs as summaries, newest first. Only the event modification counts will be shown, " +
+			"broken down by event type.").
 		Writes(gcbapi.ListChangeLogsResponse{}).
 		Param(c.ws.QueryParameter("limit", "The number of change log entries to return. Default is 6").
-			DataType("int").DefaultValue("6").Minimum(0).Maximum(100)))
+			DataType("int").DefaultValue("6").Minimum(1).Maximum(100)).
+		Param(c.ws.QueryParameter("before", "Only list change logs created before this RFC3339 date").
+			DataType("string")).
+		Param(c.ws.QueryParameter("after", "Only list change logs created after this RFC3339 date").
+			DataType("string")).
+		Param(c.ws.QueryParameter("nonEmpty", "Leave out change logs that did not create, update, or delete any events").
+			DataType("boolean").DefaultValue("false")).
+		Param(c.ws.QueryParameter("cursor", "Continue a previous listing from its nextCursor token. The before, after, and nonEmpty filters must match the listing the cursor came from.").
+			DataType("string")))
 
 	c.ws.Route(c.ws.GET("/fetch").To(c.FetchChangeLog).
 		Doc("Fetch the desired change log, fully hydrating event details").
 		Writes(gcbapi.FetchChangeLogResponse{}).
 		Param(c.ws.QueryParameter("id", "What change log id to fetch").
-			DataType("string").Required(true)))
+			DataType("string").Required(true)).
+		Param(c.ws.QueryParameter("changedFields", "Comma-separated event fields (e.g., startDateTime,roomName,cost). Only updated events where one of the fields changed are returned. Unknown fields are rejected.").
+			DataType("string")))
+
+	c.ws.Route(c.ws.GET("/stream").To(c.StreamChangeLogs).
+		Doc("Stream the summary of each new change log as a Server-Sent Event, using the change log id as the event id. " +
+			"Reconnecting with the Last-Event-ID header first replays every change log created after that id.").
+		Produces("text/event-stream").
+		Writes(gcbapi.ChangeLogSummary{}).
+		Param(c.ws.HeaderParameter("Last-Event-ID", "The id of the last change log received, to resume the stream from").
+			DataType("string")))
 
 	restful.Add(c.ws)
 
@@ -63,7 +91,8 @@ func (c *ChangeLogHandler) Register() error {
 func (c *ChangeLogHandler) ListChangeLogs(req *restful.Request, resp *restful.Response) {
 	var (
 		response gcbapi.ListChangeLogsResponse
-		limit    int = 6
+		listReq  = changelog.ListEntriesRequest{Limit: 6}
+		cursor   event.Cursor
 	)
 
 	defer func() {
@@ -98,7 +127,63 @@ func (c *ChangeLogHandler) ListChangeLogs(req *restful.Request, resp *restful.Re
 				return
 			}
 
-			limit = i
+			if i < 1 || i > 100 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("limit must be between 1 and 100, got %d", i)
+				return
+			}
+
+			listReq.Limit = i
+		case "before", "after":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("only 1 %s query parameter is allowed", queryParam)
+				return
+			}
+
+			date, err := time.Parse(time.RFC3339, values[0])
+			if err != nil {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("invalid RFC3339 date for %s: %s", queryParam, err)
+				return
+			}
+
+			if queryParam == "before" {
+				listReq.Before = date
+			} else {
+				listReq.After = date
+			}
+		case "nonEmpty":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = "only 1 nonEmpty query parameter is allowed"
+				return
+			}
+
+			b, err := strconv.ParseBool(values[0])
+			if err != nil {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("invalid boolean for nonEmpty: %s", err)
+				return
+			}
+
+			listReq.NonEmpty = b
+		case "cursor":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = "only 1 cursor query parameter is allowed"
+				return
+			}
+
+			var err error
+			cursor, err = c.cursors.Decode(values[0], event.ChangeLogCursor)
+			if err != nil {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("invalid cursor: %s", err)
+				return
+			}
+
+			listReq.SearchAfter = cursor.SearchAfter
 		default:
 			c.logger.Warn().Msgf("list change log entries attempted with unknown query parameter [%s]", queryParam)
 			resp.WriteHeader(http.StatusBadRequest)
@@ -107,7 +192,19 @@ func (c *ChangeLogHandler) ListChangeLogs(req *restful.Request, resp *restful.Re
 		}
 	}
 
-	summaries, err := c.manager.ListChangeLogSummaries(req.Request.Context(), limit)
+	if !listReq.Before.IsZero() && !listReq.Before.After(listReq.After) {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "before must be later than after"
+		return
+	}
+
+	if len(listReq.SearchAfter) != 0 && cursor.Filters != listReq.FilterHash() {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "cursor can only continue a listing with the same filters it was created with"
+		return
+	}
+
+	summaries, searchAfter, err := c.manager.ListChangeLogPage(req.Request.Context(), listReq)
 	if err != nil {
 		c.logger.Err(err).Msg("failed to list change log summaries")
 		resp.WriteHeader(http.StatusInternalServerError)
@@ -118,14 +215,27 @@ func (c *ChangeLogHandler) ListChangeLogs(req *restful.Request, resp *restful.Re
 	response = gcbapi.ListChangeLogsResponse{
 		Entries: summaries,
 	}
+
+	if len(searchAfter) > 0 {
+		response.NextCursor, err = c.cursors.Encode(event.Cursor{
+			Kind:        event.ChangeLogCursor,
+			SearchAfter: searchAfter,
+			Filters:     listReq.FilterHash(),
+		})
+		if err != nil {
+			// the page is still good, the client just cannot continue from it
+			c.logger.Warn().Err(err).Msg("failed to encode the next change log cursor")
+		}
+	}
 	resp.WriteHeader(http.StatusOK)
 }
 
 // FetchChangeLog fetches the specific changelog based on the id
 func (c *ChangeLogHandler) FetchChangeLog(req *restful.Request, resp *restful.Response) {
 	var (
-		response gcbapi.FetchChangeLogResponse
-		id       string
+		response      gcbapi.FetchChangeLogResponse
+		id            string
+		changedFields []string
 	)
 
 	defer func() {
@@ -154,6 +264,31 @@ func (c *ChangeLogHandler) FetchChangeLog(req *restful.Request, resp *restful.Re
 			}
 
 			id = values[0]
+		case "changedFields":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = "only 1 changedFields query parameter is allowed"
+				return
+			}
+
+			for _, f := range strings.Split(values[0], ",") {
+				if f = strings.TrimSpace(f); f == "" {
+					continue
+				}
+
+				field, err := event.FieldFromString(f)
+				if err == nil && field.IsVirtual() {
+					err = fmt.Errorf("%s is a virtual field and never changes", field)
+				}
+
+				if err != nil {
+					resp.WriteHeader(http.StatusBadRequest)
+					response.Error = fmt.Sprintf("invalid changedFields param: %s", err)
+					return
+				}
+
+				changedFields = append(changedFields, string(field))
+			}
 		default:
 			c.logger.Warn().Msgf("fetch change log entries attempted with unknown query parameter [%s]", queryParam)
 			resp.WriteHeader(http.StatusBadRequest)
@@ -167,7 +302,7 @@ func (c *ChangeLogHandler) FetchChangeLog(req *restful.Request, resp *restful.Re
 		return
 	}
 
-	entry, err := c.manager.FetchChangeLogEntry(req.Request.Context(), id)
+	entry, err := c.manager.FetchChangeLogEntry(req.Request.Context(), id, changedFields)
 	if err != nil {
 		c.logger.Err(err).
 			Str("change_log_id", id).
@@ -182,3 +317,86 @@ func (c *ChangeLogHandler) FetchChangeLog(req *restful.Request, resp *restful.Re
 		Entry: entry,
 	}
 }
+
+// StreamChangeLogs pushes the summary of every new change log to the client until it disconnects
+func (c *ChangeLogHandler) StreamChangeLogs(req *restful.Request, resp *restful.Response) {
+	lastEventID := req.HeaderParameter("Last-Event-ID")
+	if lastEventID != "" {
+		id, err := uuid.Parse(lastEventID)
+		if err != nil || id.Version() != 7 {
+			resp.WriteErrorString(http.StatusBadRequest, fmt.Sprintf("invalid Last-Event-ID, expected a change log id: %s", lastEventID))
+			return
+		}
+	}
+
+	// subscribe before catching up, so nothing created in between is missed
+	summaries, unsubscribe := c.stream.Subscribe()
+	defer unsubscribe()
+
+	resp.Header().Set("Content-Type", "text/event-stream")
+	resp.Header().Set("Cache-Control", "no-cache")
+	resp.Header().Set("Connection", "keep-alive")
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(resp, "retry: %d\n\n", changeLogStreamRetry); err != nil {
+		return
+	}
+	resp.Flush()
+
+	ctx := req.Request.Context()
+	sentID := lastEventID
+
+	for sentID != "" {
+		missed, err := c.manager.ListChangeLogSummariesAfter(ctx, sentID, changeLogStreamBatch)
+		if err != nil {
+			c.logger.Err(err).
+				Str("last_event_id", lastEventID).
+				Msg("failed to list the missed change logs")
+			return
+		}
+
+		for _, summary := range missed {
+			if err := writeChangeLogEvent(resp, summary); err != nil {
+				return
+			}
+			sentID = summary.ID
+		}
+		resp.Flush()
+
+		if len(missed) < changeLogStreamBatch {
+			break
+		}
+	}
+
+	heartbeat := time.NewTicker(changeLogStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(resp, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case summary, ok := <-summaries:
+			if !ok {
+				return
+			}
+
+			// change log ids are UUIDv7s, so anything at or before the last sent id was already caught up on
+			if sentID != "" && summary.ID <= sentID {
+				continue
+			}
+
+			if err := writeChangeLogEvent(resp, summary); err != nil {
+				c.logger.Debug().Err(err).Msg("failed to write change log event, closing stream")
+				return
+			}
+			sentID = summary.ID
+		}
+
+		resp.Flush()
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/changelog"
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+func TestFetchChangeLog_ChangedFields(t *testing.T) {
+	logger := zerolog.Nop()
+	handler := NewChangeLogHandler(&logger, NewChangeLogManager(&logger, nil, nil), nil, nil)
+
+	tests := []struct {
+		name          string
+		changedFields string
+		wantErr       string
+	}{
+		{name: "unknown field", changedFields: "cost,bogus", wantErr: "field value bogus is unsupported"},
+		{name: "virtual field", changedFields: "fitsIn", wantErr: "fitsIn is a virtual field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"id": {"id"}, "changedFields": {tt.changedFields}}
+			req := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/api/changelog/fetch?"+query.Encode(), nil))
+			recorder := httptest.NewRecorder()
+			handler.FetchChangeLog(req, restful.NewResponse(recorder))
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var got gcbapi.FetchChangeLogResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Contains(t, got.Error, tt.wantErr)
+		})
+	}
+}
+
+func TestListChangeLogs_CursorFilters(t *testing.T) {
+	cursors, err := event.NewCursorSigner("secret")
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	handler := NewChangeLogHandler(&logger, NewChangeLogManager(&logger, nil, nil), nil, cursors)
+
+	after := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+	token, err := cursors.Encode(event.Cursor{
+		Kind:        event.ChangeLogCursor,
+		SearchAfter: []byte(`[1722384000000,"id"]`),
+		Filters:     changelog.ListEntriesRequest{After: after, NonEmpty: true}.FilterHash(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query url.Values
+	}{
+		{name: "filter dropped", query: url.Values{"after": {after.Format(time.RFC3339)}}},
+		{name: "filter changed", query: url.Values{"after": {after.Add(time.Hour).Format(time.RFC3339)}, "nonEmpty": {"true"}}},
+		{name: "filter added", query: url.Values{"after": {after.Format(time.RFC3339)}, "nonEmpty": {"true"}, "before": {after.Add(time.Hour).Format(time.RFC3339)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Set("cursor", token)
+			req := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/api/changelog/list?"+tt.query.Encode(), nil))
+			recorder := httptest.NewRecorder()
+			handler.ListChangeLogs(req, restful.NewResponse(recorder))
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var got gcbapi.ListChangeLogsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Contains(t, got.Error, "same filters")
+		})
+	}
+}
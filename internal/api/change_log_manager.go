@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/rs/zerolog"
 
@@ -16,7 +15,7 @@ import (
 type ChangeLogManager struct {
 	logger        *zerolog.Logger
 	changeLogRepo *changelog.Repo
-	eventRepo     *event.EventRepo
+	hydrator      changelog.EntryHydrator
 }
 
 // NewChangeLogManager instantiates a new [ChangeLogManager]
@@ -24,7 +23,7 @@ func NewChangeLogManager(loger *zerolog.Logger, changeLogRepo *changelog.Repo, e
 	return ChangeLogManager{
 		logger:        loger,
 		changeLogRepo: changeLogRepo,
-		eventRepo:     eventRepo,
+		hydrator:      changelog.NewEntryHydrator(loger, changeLogRepo, eventRepo),
 	}
 }
 
@@ -38,117 +37,46 @@ func (m ChangeLogManager) ListChangeLogSummaries(ctx context.Context, numEntries
 		return nil, err
 	}
 
-	summaries := make([]gcbapi.ChangeLogSummary, len(entries))
-	for i, e := range entries {
-		summaries[i] = gcbapi.ChangeLogSummary{
-			ID:           e.ID,
-			Date:         e.Date,
-			UpdatedCount: len(e.UpdatedEvents),
-			DeletedCount: len(e.DeletedEvents),
-			CreatedCount: len(e.CreatedEvents),
-		}
-	}
-
-	return summaries, nil
+	return summarizeChangeLogs(entries.Entries), nil
 }
 
-// FetchChangeLogEntry fetches the desired change log and hydrates the event data
-func (m ChangeLogManager) FetchChangeLogEntry(ctx context.Context, id string) (gcbapi.ChangeLogEntry, error) {
-	fetchResponse, err := m.changeLogRepo.FetchEntries(ctx, id)
+// ListChangeLogPage fetches a page of change log entries matching the request, and summarizes them before returning.
+// The returned search after continues the listing, and is nil on the last page.
+func (m ChangeLogManager) ListChangeLogPage(ctx context.Context, req changelog.ListEntriesRequest) ([]gcbapi.ChangeLogSummary, []byte, error) {
+	resp, err := m.changeLogRepo.List(ctx, req)
 	if err != nil {
-		return gcbapi.ChangeLogEntry{}, err
+		return nil, nil, err
 	}
 
-	if len(fetchResponse.Missing) > 0 {
-		return gcbapi.ChangeLogEntry{}, fmt.Errorf("could not find change log entry [%s]", id)
-	}
-
-	if len(fetchResponse.Found) != 1 {
-		m.logger.Warn().Msgf("expected a single change log entry for id [%s], instead found %d", id, len(fetchResponse.Found))
-	}
+	return summarizeChangeLogs(resp.Entries), resp.SearchAfter, nil
+}
 
-	entry, ok := fetchResponse.Found[id]
-	if !ok {
-		return gcbapi.ChangeLogEntry{}, fmt.Errorf("found results for change log entry id [%s], but no entry in result map", id)
-	}
+// ListChangeLogSummariesAfter fetches up to limit change log entries created after the afterID entry,
+// oldest first, and summarizes them before returning
+func (m ChangeLogManager) ListChangeLogSummariesAfter(ctx context.Context, afterID string, limit int) ([]gcbapi.ChangeLogSummary, error) {
+	entries, err := m.changeLogRepo.ListAfter(ctx, changelog.ListAfterRequest{
+		AfterID: afterID,
+		Limit:   limit,
+	})
 
-	respEntry := gcbapi.ChangeLogEntry{
-		ID:            id,
-		Date:          entry.Date,
-		UpdatedEvents: make([]gcbapi.Event, 0, len(entry.UpdatedEvents)),
-		DeletedEvents: make([]gcbapi.Event, 0, len(entry.DeletedEvents)),
-		CreatedEvents: make([]gcbapi.Event, 0, len(entry.CreatedEvents)),
+	if err != nil {
+		return nil, err
 	}
 
-	var eventFetchResponse event.FetchEventsResponse
-	if len(entry.CreatedEvents) > 0 {
-		eventFetchResponse, err = m.eventRepo.FetchEvents(ctx, entry.CreatedEvents...)
-		if err != nil {
-			return respEntry, fmt.Errorf("failed to fetch created events for change log [%s]: %w", id, err)
-		}
-
-		for _, id := range entry.CreatedEvents {
-			e, ok := eventFetchResponse.Found[id]
-			if !ok {
-				continue
-			}
-
-			if e == nil {
-				m.logger.Warn().
-					Str("event_id", id).
-					Msg("event repo returned nil for created event, skipping")
-				continue
-			}
-
-			respEntry.CreatedEvents = append(respEntry.CreatedEvents, e.Externalize())
-		}
-	}
+	return summarizeChangeLogs(entries), nil
+}
 
-	if len(entry.UpdatedEvents) > 0 {
-		eventFetchResponse, err = m.eventRepo.FetchEvents(ctx, entry.UpdatedEvents...)
-		if err != nil {
-			return respEntry, fmt.Errorf("failed to fetch updated events for change log [%s]: %w", id, err)
-		}
-
-		for _, id := range entry.UpdatedEvents {
-			e, ok := eventFetchResponse.Found[id]
-			if !ok {
-				continue
-			}
-
-			if e == nil {
-				m.logger.Warn().
-					Str("event_id", id).
-					Msg("event repo returned nil for updated event, skipping")
-				continue
-			}
-
-			respEntry.UpdatedEvents = append(respEntry.UpdatedEvents, e.Externalize())
-		}
+func summarizeChangeLogs(entries []*changelog.Entry) []gcbapi.ChangeLogSummary {
+	summaries := make([]gcbapi.ChangeLogSummary, len(entries))
+	for i, e := range entries {
+		summaries[i] = e.Summarize()
 	}
 
-	if len(entry.DeletedEvents) > 0 {
-		eventFetchResponse, err = m.eventRepo.FetchEvents(ctx, entry.DeletedEvents...)
-		if err != nil {
-			return respEntry, fmt.Errorf("failed to fetch deleted events for change log [%s]: %w", id, err)
-		}
-
-		for _, id := range entry.DeletedEvents {
-			e, ok := eventFetchResponse.Found[id]
-			if !ok {
-				continue
-			}
-
-			if e == nil {
-				m.logger.Warn().
-					Str("event_id", id).
-					Msg("event repo returned nil for deleted event, skipping")
-				continue
-			}
-
-			respEntry.DeletedEvents = append(respEntry.DeletedEvents, e.Externalize())
-		}
-	}
+	return summaries
+}
 
-	return respEntry, nil
+// FetchChangeLogEntry fetches the desired change log and hydrates the event data.
+// When changedFields is not empty, only the updated events where one of those fields changed are included.
+func (m ChangeLogManager) FetchChangeLogEntry(ctx context.Context, id string, changedFields []string) (gcbapi.ChangeLogEntry, error) {
+	return m.hydrator.FetchEntry(ctx, id, changedFields)
 }
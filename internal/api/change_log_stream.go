@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/gcbapi"
+)
+
+const (
+	// changeLogStreamInterval is how often the change log index is checked for new entries
+	changeLogStreamInterval = 5 * time.Second
+	// changeLogStreamHeartbeat is how often an idle stream sends a comment, so proxies keep the connection open
+	changeLogStreamHeartbeat = 30 * time.Second
+	// changeLogStreamRetry is how long clients wait before reconnecting, in milliseconds
+	changeLogStreamRetry = 5000
+	changeLogStreamBatch = 100
+	// changeLogStreamBuffer is how many summaries a subscriber can fall behind before it is dropped
+	changeLogStreamBuffer = 16
+)
+
+// changeLogLister is the part of the [ChangeLogManager] the stream polls
+type changeLogLister interface {
+	ListChangeLogSummaries(ctx context.Context, numEntries int) ([]gcbapi.ChangeLogSummary, error)
+	ListChangeLogSummariesAfter(ctx context.Context, afterID string, limit int) ([]gcbapi.ChangeLogSummary, error)
+}
+
+// ChangeLogStream watches the change log index for new entries and fans
+// their summaries out to every subscriber. Only entries created after the
+// stream starts are published.
+type ChangeLogStream struct {
+	logger   *zerolog.Logger
+	lister   changeLogLister
+	interval time.Duration
+
+	// primed is set once the latest entry at start up is known, latestID is empty if there was none
+	primed   bool
+	latestID string
+
+	mu          sync.Mutex
+	subscribers map[chan gcbapi.ChangeLogSummary]struct{}
+}
+
+// NewChangeLogStream instantiates a [ChangeLogStream]
+func NewChangeLogStream(logger *zerolog.Logger, lister changeLogLister, interval time.Duration) *ChangeLogStream {
+	return &ChangeLogStream{
+		logger:      logger,
+		lister:      lister,
+		interval:    interval,
+		subscribers: make(map[chan gcbapi.ChangeLogSummary]struct{}),
+	}
+}
+
+// Run polls for new change log entries every interval until the context is done,
+// then closes every subscription.
+func (s *ChangeLogStream) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.poll(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Warn().Err(err).Msg("failed to poll for new change log entries")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.closeAll()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Subscribe to new change log summaries. The channel is closed when the subscriber falls too
+// far behind or the stream stops, and unsubscribe must be called once the subscriber is done.
+func (s *ChangeLogStream) Subscribe() (summaries <-chan gcbapi.ChangeLogSummary, unsubscribe func()) {
+	ch := make(chan gcbapi.ChangeLogSummary, changeLogStreamBuffer)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (s *ChangeLogStream) poll(ctx context.Context) error {
+	if !s.primed {
+		latest, err := s.lister.ListChangeLogSummaries(ctx, 1)
+		if err != nil {
+			return fmt.Errorf("failed to find the latest change log entry: %w", err)
+		}
+
+		if len(latest) > 0 {
+			s.latestID = latest[0].ID
+		}
+
+		s.primed = true
+		return nil
+	}
+
+	for {
+		var (
+			summaries []gcbapi.ChangeLogSummary
+			err       error
+		)
+
+		if s.latestID == "" {
+			// there were no entries at start up, so everything is new. Listing is newest first.
+			summaries, err = s.lister.ListChangeLogSummaries(ctx, changeLogStreamBatch)
+			slices.Reverse(summaries)
+		} else {
+			summaries, err = s.lister.ListChangeLogSummariesAfter(ctx, s.latestID, changeLogStreamBatch)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to list the new change log entries: %w", err)
+		}
+
+		for _, summary := range summaries {
+			s.publish(summary)
+			s.latestID = summary.ID
+		}
+
+		if len(summaries) < changeLogStreamBatch {
+			return nil
+		}
+	}
+}
+
+func (s *ChangeLogStream) publish(summary gcbapi.ChangeLogSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- summary:
+		default:
+			// the subscriber will resume with its Last-Event-ID once it reconnects
+			s.logger.Warn().Msg("change log stream subscriber fell behind, dropping it")
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (s *ChangeLogStream) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+// writeChangeLogEvent writes the summary as a server sent event, using the change log id as the event id
+func writeChangeLogEvent(w io.Writer, summary gcbapi.ChangeLogSummary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to marshal change log summary %s: %w", summary.ID, err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: changelog\ndata: %s\n\n", summary.ID, data)
+	return err
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+)
+
+// fakeChangeLogLister serves summaries oldest first, keyed on their position rather than real UUIDv7s
+type fakeChangeLogLister struct {
+	summaries []gcbapi.ChangeLogSummary
+}
+
+func (f *fakeChangeLogLister) ListChangeLogSummaries(_ context.Context, numEntries int) ([]gcbapi.ChangeLogSummary, error) {
+	var newestFirst []gcbapi.ChangeLogSummary
+	for i := len(f.summaries) - 1; i >= 0 && len(newestFirst) < numEntries; i-- {
+		newestFirst = append(newestFirst, f.summaries[i])
+	}
+
+	return newestFirst, nil
+}
+
+func (f *fakeChangeLogLister) ListChangeLogSummariesAfter(_ context.Context, afterID string, limit int) ([]gcbapi.ChangeLogSummary, error) {
+	var after []gcbapi.ChangeLogSummary
+	for _, s := range f.summaries {
+		if s.ID > afterID && len(after) < limit {
+			after = append(after, s)
+		}
+	}
+
+	return after, nil
+}
+
+func receive(t *testing.T, summaries <-chan gcbapi.ChangeLogSummary) []string {
+	t.Helper()
+
+	var ids []string
+	for {
+		select {
+		case s, ok := <-summaries:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, s.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestChangeLogStreamPublishesNewEntries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing []string
+		created  []string
+	}{
+		{
+			name:     "existing entries are not published",
+			existing: []string{"01", "02"},
+			created:  []string{"03", "04"},
+		},
+		{
+			name:    "empty index",
+			created: []string{"01", "02"},
+		},
+		{
+			name:     "nothing new",
+			existing: []string{"01"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := zerolog.Nop()
+			lister := &fakeChangeLogLister{}
+			for _, id := range tc.existing {
+				lister.summaries = append(lister.summaries, gcbapi.ChangeLogSummary{ID: id})
+			}
+
+			stream := NewChangeLogStream(&logger, lister, time.Second)
+			summaries, unsubscribe := stream.Subscribe()
+			defer unsubscribe()
+
+			require.NoError(t, stream.poll(context.Background()))
+			require.Empty(t, receive(t, summaries))
+
+			for _, id := range tc.created {
+				lister.summaries = append(lister.summaries, gcbapi.ChangeLogSummary{ID: id})
+			}
+
+			require.NoError(t, stream.poll(context.Background()))
+			require.Equal(t, tc.created, receive(t, summaries))
+
+			require.NoError(t, stream.poll(context.Background()))
+			require.Empty(t, receive(t, summaries))
+		})
+	}
+}
+
+func TestChangeLogStreamDropsSlowSubscribers(t *testing.T) {
+	logger := zerolog.Nop()
+	stream := NewChangeLogStream(&logger, &fakeChangeLogLister{}, time.Second)
+
+	summaries, unsubscribe := stream.Subscribe()
+	defer unsubscribe()
+
+	for range changeLogStreamBuffer + 1 {
+		stream.publish(gcbapi.ChangeLogSummary{ID: "id"})
+	}
+
+	require.Len(t, receive(t, summaries), changeLogStreamBuffer)
+	_, ok := <-summaries
+	require.False(t, ok, "the slow subscriber should be closed")
+}
+
+func TestWriteChangeLogEvent(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeChangeLogEvent(&buf, gcbapi.ChangeLogSummary{
+		ID:           "0192a3b4-c5d6-7e8f-9012-3456789abcde",
+		Date:         "2025-07-31T09:00:00-04:00",
+		UpdatedCount: 2,
+		DeletedCount: 1,
+		CreatedCount: 3,
+		EventTypes: []gcbapi.EventTypeCounts{
+			{EventType: "RPG", UpdatedCount: 2, DeletedCount: 1, CreatedCount: 3},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "id: 0192a3b4-c5d6-7e8f-9012-3456789abcde\n"+
+		"event: changelog\n"+
+		`data: {"id":"0192a3b4-c5d6-7e8f-9012-3456789abcde","date":"2025-07-31T09:00:00-04:00","updatedCount":2,"deletedCount":1,"createdCount":3,`+
+		`"eventTypes":[{"eventType":"RPG","updatedCount":2,"deletedCount":1,"createdCount":3}]}`+"\n\n",
+		buf.String())
+}
@@ -1,17 +1,23 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful/v3"
 	"github.com/rs/zerolog"
 
 	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/calendar"
 	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/search"
 )
 
 // EventHandler is the API Handler for all /api/events/* endpoints.
@@ -19,14 +25,16 @@ type EventHandler struct {
 	logger  *zerolog.Logger
 	ws      *restful.WebService
 	manager EventManager
+	cursors *event.CursorSigner
 }
 
 // NewEventHandler instantiates an [EventHandler].
-func NewEventHandler(logger *zerolog.Logger, manager EventManager) *EventHandler {
+func NewEventHandler(logger *zerolog.Logger, manager EventManager, cursors *event.CursorSigner) *EventHandler {
 	return &EventHandler{
 		logger:  logger,
 		ws:      new(restful.WebService),
 		manager: manager,
+		cursors: cursors,
 	}
 }
 
@@ -41,33 +49,147 @@ func (e *EventHandler) Register() {
 		Writes(gcbapi.EventSearchResponse{}). // TODO
 		Param(e.ws.QueryParameter("filter", "The value to perform the search with.").
 			DataType("string").AllowEmptyValue(true)).
+		Param(e.ws.QueryParameter("q", "A query string combined with the other search params, e.g. "+
+			`eventType:RPG AND (cost:<=4 OR title:"dungeon crawl") NOT gmNames:smith. `+
+			"Clauses are field:value, with the value formatted like the field's own query param, or compared with <, <=, >, or >= on number and date fields. "+
+			"Quoted values match text fields as a phrase, and bare words search like filter. Terms are combined with AND, OR, NOT, and parentheses, "+
+			"where terms without an operator are ANDed. Parse errors report the column of the problem.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("day", "Comma-separated days the events start on in America/Indianapolis time, e.g. thu,fri.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("startHour", "The hour the events start in America/Indianapolis time, e.g. [18,23]. "+
+			"endHour is the same, with events ending after midnight counting past 23.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("fitsIn", "Comma-separated time windows the events must start and end inside, "+
+			"e.g. [2025-07-31T10:00:00-04:00,2025-07-31T14:00:00-04:00],[2025-08-01T18:00:00-04:00,2025-08-01T23:00:00-04:00]. "+
+			"Events fitting any of the windows match.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("hasBgg", "Only return events that were (true) or were not (false) matched to a BoardGameGeek game. "+
+			"bggId, bggRank, and bggAvgRating can be searched, sorted, and faceted like the other fields.").
+			DataType("boolean")).
+		Param(e.ws.QueryParameter("partySize", "The number of people playing together, e.g. 4. Events must allow that many players and have that many tickets available, "+
+			"except generic ticket-only events. Add kids, e.g. 4,kids, to only match kids only and everyone events.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("excludeConflictsWith", "Comma-separated game ids of events, ie a schedule, whose times the returned events cannot overlap. "+
+			"Unknown and deleted events are ignored.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("conflictBuffer", "Minutes of walking time kept free before and after each excludeConflictsWith event (0 to 240).").
+			DataType("int").DefaultValue("0").Minimum(0).Maximum(240)).
 		Param(e.ws.QueryParameter("limit", "The number of events to return. Default is 100.").
 			DataType("int").DefaultValue("100").Minimum(0).Maximum(5000)).
 		Param(e.ws.QueryParameter("page", "What page of events to return. Pages are based on the limit. Default is 0").
 			DataType("int").DefaultValue("0").Minimum(0).Maximum(100)).
 		Param(e.ws.QueryParameter("sort", "Sort events by one or more fields as comma-separated {field}.{asc|desc} pairs (e.g., startDateTime.asc,title.desc).").
-			DataType("string").DefaultValue("")))
+			DataType("string").DefaultValue("")).
+		Param(e.ws.QueryParameter("cursor", "Continue a previous search from its meta.nextCursor token. Cannot be combined with page, keeps the sort of the original search, and must be sent with the same filters.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("facets", "Comma-separated fields to return value counts for in meta.facets. Each field's counts apply every other filter but its own. Supported fields: eventType, gameSystem, group, location, roomName, ageRequired, experienceRequired, attendeeRegistration, specialCategory, day, bggId.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("highlight", "Return the matching fragments of the filter and text search fields in each event's meta.highlights, with matches wrapped in <em> tags. The event text in each fragment is HTML-escaped.").
+			DataType("boolean").DefaultValue("false")))
+
+	e.ws.Route(e.ws.POST("/search").To(e.SearchBody).
+		Doc("Search for events with a structured query. Conditions on a field take the same values as its GET search query param, " +
+			"so equivalent searches return the same events. Groups nest to combine conditions with and, or, and not. " +
+			"Pagination links are not returned, continue with meta.nextCursor or the page instead.").
+		Reads(gcbapi.EventSearchQuery{}).
+		Writes(gcbapi.EventSearchResponse{}))
 
 	e.ws.Route(e.ws.GET("/facets/{field}").To(e.Facets).
-		Doc("Get all distinct values with event counts for a supported keyword field").
+		Doc("Get event counts for a supported field. Keyword fields return their distinct values as a KeywordFacetsResponse, " +
+			"numeric and date fields return histogram buckets as a HistogramFacetsResponse. " +
+			"Any other search query parameter filters the counted events, except a filter on the faceted field itself and excludeConflictsWith, which is not supported.").
+		// restful only takes a single model per route, the histogram shape is described in the doc
 		Writes(gcbapi.KeywordFacetsResponse{}).
-		Param(e.ws.PathParameter("field", "The field to facet on. Supported fields: gameSystem, group, location, roomName.").
+		Param(e.ws.PathParameter("field", "The field to facet on. Supported keyword fields: eventType, gameSystem, group, location, roomName, ageRequired, experienceRequired, attendeeRegistration, specialCategory, day, bggId. "+
+			"Supported histogram fields: cost, duration, ticketsAvailable, bggAvgRating, bggRank, startHour, endHour, startDateTime.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("size", "Maximum number of values to return for keyword fields. Default is 100, max is 5000.").
+			DataType("int").DefaultValue("100")).
+		Param(e.ws.QueryParameter("interval", "Histogram bucket width for numeric fields (default 5 for cost and ticketsAvailable, 100 for bggRank, 1 for duration, bggAvgRating, startHour, and endHour), "+
+			"or day|hour for startDateTime (default day, in America/Indianapolis time).").
+			DataType("string")).
+		Param(e.ws.QueryParameter("ranges", "Explicit numeric buckets instead of an interval, as comma-separated [min,max) ranges (e.g., [,4),[4,20),[20,)).").
+			DataType("string")))
+
+	e.ws.Route(e.ws.GET("/suggest").To(e.Suggest).
+		Doc("Search-as-you-type completions for event titles, game systems, groups, and GM names, ranked with their event counts").
+		Writes(gcbapi.SuggestResponse{}).
+		Param(e.ws.QueryParameter("q", "The partial text to complete.").
+			DataType("string").Required(true)).
+		Param(e.ws.QueryParameter("limit", "The number of suggestions to return. Default is 10, max is 50.").
+			DataType("int").DefaultValue("10").Minimum(1).Maximum(maxSuggestLimit)))
+
+	e.ws.Route(e.ws.GET("/calendar.ics").To(e.CalendarEvents).
+		Doc("Export events as an iCalendar file. Each event keeps a stable UID, so importing again updates existing calendar entries. Soft-deleted events are exported as cancelled.").
+		Produces(calendarContentType).
+		Param(e.ws.QueryParameter("ids", "Comma-separated game ids of the events to export.").
+			DataType("string").Required(true)))
+
+	e.ws.Route(e.ws.GET("/search.ics").To(e.CalendarSearch).
+		Doc("Export every event matching a search as an iCalendar file. Accepts the same filter and sort query parameters as /search. " +
+			"Soft-deleted matches are exported as cancelled, so subscribed calendars drop them.").
+		Produces(calendarContentType))
+
+	e.ws.Route(e.ws.GET("/export").To(e.Export).
+		Doc("Export every event matching a search as a spreadsheet with Gen Con's event file headers. Accepts the same filter and sort query parameters as /search.").
+		Produces(csvContentType, xlsxContentType).
+		Param(e.ws.QueryParameter("format", "The spreadsheet format, csv or xlsx. Default is csv.").
+			DataType("string").DefaultValue("csv")))
+
+	e.ws.Route(e.ws.POST("/fetch").To(e.FetchEvents).
+		Doc("Fetch a batch of events by their game ids. Soft-deleted events are included and flagged as deleted.").
+		Reads(gcbapi.EventFetchRequest{}).
+		Writes(gcbapi.EventFetchResponse{}))
+
+	e.ws.Route(e.ws.GET("/{id}/similar").To(e.Similar).
+		Doc("Find events similar to an event by title, descriptions, game system, and group, favoring the same event type and BGG game. " +
+			"The event itself, soft-deleted events, and sold out events are excluded.").
+		Writes(gcbapi.SimilarEventsResponse{}).
+		Param(e.ws.PathParameter("id", "The game id of the event to find similar events for").
+			DataType("string")).
+		Param(e.ws.QueryParameter("limit", "The number of events to return. Default is 10, max is 100.").
+			DataType("int").DefaultValue("10").Minimum(1).Maximum(maxSimilarLimit)).
+		Param(e.ws.QueryParameter("includeSoldOut", "Include events without tickets available.").
+			DataType("boolean").DefaultValue("false")).
+		Param(e.ws.QueryParameter("overlapping", "Only return events that overlap the time of the event.").
+			DataType("boolean").DefaultValue("false")).
+		Param(e.ws.QueryParameter("start", "Only return events that end after this RFC 3339 time. Cannot be combined with overlapping.").
+			DataType("string")).
+		Param(e.ws.QueryParameter("end", "Only return events that start before this RFC 3339 time. Cannot be combined with overlapping.").
+			DataType("string")))
+
+	e.ws.Route(e.ws.GET("/{id}/tickets/history").To(e.TicketHistory).
+		Doc("Fetch the ticket availability snapshots of an event, oldest first, recorded whenever its available tickets changed. " +
+			"Includes the tickets sold, percent sold, tickets sold per hour over the last 24 hours, and the projected sell-out time at that rate. " +
+			"Search with sort=ticketsSoldPerHour.desc to find the fastest selling events.").
+		Writes(gcbapi.TicketHistoryResponse{}).
+		Param(e.ws.PathParameter("id", "The game id of the event").
 			DataType("string")).
-		Param(e.ws.QueryParameter("size", "Maximum number of values to return. Default is 100, max is 5000.").
-			DataType("int").DefaultValue("100")))
+		Param(e.ws.QueryParameter("limit", "The number of most recent snapshots to return. Default is 500, max is 5000.").
+			DataType("int").DefaultValue("500").Minimum(1).Maximum(maxTicketHistoryLimit)))
+
+	e.ws.Route(e.ws.GET("/{id}/history").To(e.EventHistory).
+		Doc("Fetch every change log that created, updated, or deleted an event, oldest first. " +
+			"Updates include the changed fields, except for change logs made before changes were recorded.").
+		Writes(gcbapi.EventHistoryResponse{}).
+		Param(e.ws.PathParameter("id", "The game id of the event").
+			DataType("string")).
+		Param(e.ws.QueryParameter("limit", "The number of most recent change logs to return. Default is 500, max is 5000.").
+			DataType("int").DefaultValue("500").Minimum(1).Maximum(maxEventHistoryLimit)))
+
+	e.ws.Route(e.ws.GET("/{id}").To(e.FetchEvent).
+		Doc("Fetch a single event by its game id. Soft-deleted events are included and flagged as deleted.").
+		Writes(gcbapi.EventFetchResponse{}).
+		Param(e.ws.PathParameter("id", "The game id of the event").
+			DataType("string")))
 
 	restful.Add(e.ws)
 }
 
 // Search handles /events/search api calls
 func (e *EventHandler) Search(req *restful.Request, resp *restful.Response) {
-	var (
-		response  gcbapi.EventSearchResponse
-		searchReq = event.SearchRequest{
-			Page:  0,
-			Limit: 100,
-		}
-	)
+	var response gcbapi.EventSearchResponse
 
 	defer func() {
 		responseBody, err := json.Marshal(response)
@@ -85,115 +207,292 @@ func (e *EventHandler) Search(req *restful.Request, resp *restful.Response) {
 		}
 	}()
 
-	for queryParam, values := range req.Request.URL.Query() {
+	searchReq, usingCursor, err := e.parseSearchRequest(req.Request.URL.Query(), false)
+	if err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = &gcbapi.Error{
+			Status: "bad request",
+			Detail: err.Error(),
+		}
+		return
+	}
+
+	response = e.search(req, resp, searchReq, usingCursor)
+}
+
+// search performs the search request, writing the response status
+func (e *EventHandler) search(req *restful.Request, resp *restful.Response, searchReq event.SearchRequest, usingCursor bool) gcbapi.EventSearchResponse {
+	var response gcbapi.EventSearchResponse
+
+	result, err := e.manager.Search(req.Request.Context(), searchReq)
+	if err != nil {
+		e.logger.Err(err).Msgf("Failed to perform search request [%+v]", searchReq)
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = &gcbapi.Error{
+			Status: "internal server error",
+			Detail: "failed executing search request",
+		}
+		return response
+	}
+
+	response.Meta.Total = result.Total
+	response.Meta.Facets = result.Facets
+	response.Data = result.Events
+
+	// a full page means there may be more events to continue to
+	if len(result.Events) == searchReq.Limit && len(result.SearchAfter) != 0 {
+		filters, err := searchReq.FilterHash()
+		if err == nil {
+			response.Meta.NextCursor, err = e.cursors.Encode(event.Cursor{
+				Kind:        event.SearchCursor,
+				Sorts:       searchReq.Sorts,
+				SearchAfter: result.SearchAfter,
+				Filters:     filters,
+			})
+		}
+		if err != nil {
+			e.logger.Warn().Err(err).Msg("failed to encode the next search cursor")
+		}
+	}
+
+	page := searchPage{
+		page:        searchReq.Page,
+		limit:       searchReq.Limit,
+		total:       result.Total,
+		nextCursor:  response.Meta.NextCursor,
+		usingCursor: usingCursor,
+	}
+
+	response.Links = paginationLinks(req.Request.URL, page)
+	response.Meta.Limit = searchReq.Limit
+	response.Meta.TotalPages = page.totalPages()
+	if !page.usingCursor {
+		response.Meta.Page = &searchReq.Page
+	}
+
+	resp.WriteHeader(http.StatusOK)
+	return response
+}
+
+// parseSearchRequest builds the event search from the search query parameters.
+// Soft-deleted events are excluded unless includeDeleted is set. The returned bool
+// reports whether the search continues from a cursor. Any error is the caller's bad request.
+func (e *EventHandler) parseSearchRequest(query url.Values, includeDeleted bool) (event.SearchRequest, bool, error) {
+	var (
+		searchReq = event.SearchRequest{
+			Page:  0,
+			Limit: 100,
+		}
+		pageSet     bool
+		cursorToken string
+	)
+
+	for queryParam, values := range query {
 		switch queryParam {
 		case "limit":
 			if len(values) > 1 {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: "only 1 limit query parameter is allowed",
-				}
-				return
+				return searchReq, false, fmt.Errorf("only 1 limit query parameter is allowed")
 			}
 
 			i, err := strconv.Atoi(values[0])
 			if err != nil {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: fmt.Sprintf("invalid integer for limit: %s", err),
-				}
-				return
+				return searchReq, false, fmt.Errorf("invalid integer for limit: %s", err)
 			}
 
 			searchReq.Limit = i
 		case "page":
 			if len(values) > 1 {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: "only 1 page query parameter is allowed",
-				}
-				return
+				return searchReq, false, fmt.Errorf("only 1 page query parameter is allowed")
 			}
 
 			i, err := strconv.Atoi(values[0])
 			if err != nil {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: fmt.Sprintf("invalid integer for page: %s", err),
-				}
-				return
+				return searchReq, false, fmt.Errorf("invalid integer for page: %s", err)
 			}
 
 			searchReq.Page = i
+			pageSet = true
+		case "cursor":
+			if len(values) > 1 {
+				return searchReq, false, fmt.Errorf("only 1 cursor query parameter is allowed")
+			}
+
+			cursorToken = values[0]
+		case "facets":
+			if len(values) > 1 {
+				return searchReq, false, fmt.Errorf("only 1 facets query parameter is allowed")
+			}
+
+			facets, err := parseSearchFacets(values[0])
+			if err != nil {
+				return searchReq, false, fmt.Errorf("invalid facets param: %s", err)
+			}
+
+			searchReq.Facets = facets
+		case "highlight":
+			if len(values) > 1 {
+				return searchReq, false, fmt.Errorf("only 1 highlight query parameter is allowed")
+			}
+
+			highlight, err := strconv.ParseBool(values[0])
+			if err != nil {
+				return searchReq, false, fmt.Errorf("invalid boolean for highlight: %s", err)
+			}
+
+			searchReq.Highlight = highlight
 		case "sort":
 			if len(values) > 1 {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: "only 1 sort query parameter is allowed",
-				}
-				return
+				return searchReq, false, fmt.Errorf("only 1 sort query parameter is allowed")
 			}
 			sorts, err := event.ParseSorts(values[0])
 			if err != nil {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: fmt.Sprintf("invalid sort param: %s", err),
-				}
-				return
+				return searchReq, false, fmt.Errorf("invalid sort param: %s", err)
 			}
 			searchReq.Sorts = sorts
+		case "excludeConflictsWith":
+			for _, v := range values {
+				ids, err := parseConflictIDs(v)
+				if err != nil {
+					return searchReq, false, fmt.Errorf("invalid excludeConflictsWith param: %s", err)
+				}
+
+				searchReq.ExcludeConflictsWith = append(searchReq.ExcludeConflictsWith, ids...)
+			}
+		case "conflictBuffer":
+			if len(values) > 1 {
+				return searchReq, false, fmt.Errorf("only 1 conflictBuffer query parameter is allowed")
+			}
+
+			buffer, err := parseConflictBuffer(values[0])
+			if err != nil {
+				return searchReq, false, fmt.Errorf("invalid conflictBuffer param: %s", err)
+			}
+
+			searchReq.ConflictBuffer = buffer
 		default:
 			// search term?
 			searchTerm, err := event.NewSearchField(queryParam, strings.Join(values, ","))
 			if err != nil {
-				resp.WriteHeader(http.StatusBadRequest)
-				response.Error = &gcbapi.Error{
-					Status: "bad request",
-					Detail: fmt.Errorf("invalid search query param %s: %w", queryParam, err).Error(),
-				}
-				return
+				return searchReq, false, fmt.Errorf("invalid search query param %s: %w", queryParam, err)
 			}
 
 			e.logger.Debug().Msgf("parsed search term from query param %s and values %v: %+v", queryParam, values, searchTerm)
 
-			searchReq.Terms = append(searchReq.Terms, searchTerm)
+			searchReq.Terms = append(searchReq.Terms, event.FieldTerm{Term: searchTerm, Field: event.Field(queryParam)})
+		}
+	}
+
+	return e.finishSearchRequest(searchReq, pageSet, cursorToken, includeDeleted)
+}
+
+// finishSearchRequest continues the search from the cursor token when there is one,
+// checks the page is reachable, and excludes soft-deleted events unless includeDeleted is set.
+// A cursor only continues a search with the same filters it was created with.
+func (e *EventHandler) finishSearchRequest(searchReq event.SearchRequest, pageSet bool, cursorToken string, includeDeleted bool) (event.SearchRequest, bool, error) {
+	var (
+		cursor event.Cursor
+		err    error
+	)
+
+	if cursorToken != "" {
+		if pageSet {
+			return searchReq, false, fmt.Errorf("page cannot be combined with cursor")
+		}
+
+		cursor, err = e.cursors.Decode(cursorToken, event.SearchCursor)
+		if err != nil {
+			return searchReq, false, fmt.Errorf("invalid cursor: %s", err)
+		}
+
+		if searchReq.Sorts != nil && !slices.Equal(searchReq.Sorts, cursor.Sorts) {
+			return searchReq, false, fmt.Errorf("sort must match the sort the cursor was created with")
 		}
+
+		searchReq.Sorts = cursor.Sorts
+		searchReq.SearchAfter = cursor.SearchAfter
+	} else if (searchReq.Page+1)*searchReq.Limit > event.MaxResultWindow {
+		return searchReq, false, fmt.Errorf("page and limit cannot reach past the first %d events, use cursor to page further", event.MaxResultWindow)
+	}
+
+	if searchReq.ConflictBuffer != 0 && len(searchReq.ExcludeConflictsWith) == 0 {
+		return searchReq, false, fmt.Errorf("conflictBuffer requires excludeConflictsWith")
 	}
 
-	var err error
+	if len(searchReq.ExcludeConflictsWith) > maxConflictEvents {
+		return searchReq, false, fmt.Errorf("cannot exclude conflicts with more than %d events", maxConflictEvents)
+	}
 
 	// only show non-deleted events
-	visibleSearchTerm, err := event.NewSearchField(string(event.Deleted), "false")
-	if err != nil {
-		e.logger.Warn().Err(err).Msg("failed to create the visibility filter")
-	} else {
-		searchReq.Terms = append(searchReq.Terms, visibleSearchTerm)
+	if !includeDeleted {
+		visibleSearchTerm, err := event.NewSearchField(string(event.Deleted), "false")
+		if err != nil {
+			e.logger.Warn().Err(err).Msg("failed to create the visibility filter")
+		} else {
+			searchReq.Terms = append(searchReq.Terms, visibleSearchTerm)
+		}
 	}
 
-	response.Meta.Total, response.Data, err = e.manager.Search(req.Request.Context(), searchReq)
-	if err != nil {
-		e.logger.Err(err).Msgf("Failed to perform search request [%+v]", searchReq)
-		resp.WriteHeader(http.StatusInternalServerError)
-		response.Error = &gcbapi.Error{
-			Status: "internal server error",
-			Detail: "failed executing search request",
+	if cursorToken != "" {
+		filters, err := searchReq.FilterHash()
+		if err != nil {
+			return searchReq, false, fmt.Errorf("invalid search terms: %s", err)
+		}
+
+		if cursor.Filters != filters {
+			return searchReq, false, fmt.Errorf("cursor can only continue a search with the same filters it was created with")
 		}
-		return
 	}
 
-	resp.WriteHeader(http.StatusOK)
+	return searchReq, cursorToken != "", nil
+}
+
+const (
+	// maxConflictEvents is the most events a search can exclude conflicts with
+	maxConflictEvents = 500
+	// maxConflictBufferMinutes is the longest buffer kept around each conflicting event
+	maxConflictBufferMinutes = 240
+)
+
+// parseConflictIDs parses a comma-separated list of game ids
+func parseConflictIDs(s string) ([]string, error) {
+	parts := strings.Split(s, ",")
+	ids := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return nil, fmt.Errorf("game ids cannot be empty")
+		}
+
+		ids = append(ids, p)
+	}
+
+	return ids, nil
+}
+
+// parseConflictBuffer parses the conflict buffer in whole minutes
+func parseConflictBuffer(s string) (time.Duration, error) {
+	minutes, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer for minutes: %s", err)
+	}
+
+	return conflictBuffer(minutes)
+}
+
+// conflictBuffer converts the conflict buffer minutes to a duration
+func conflictBuffer(minutes int) (time.Duration, error) {
+	if minutes < 0 || minutes > maxConflictBufferMinutes {
+		return 0, fmt.Errorf("buffer must be between 0 and %d minutes, got %d", maxConflictBufferMinutes, minutes)
+	}
+
+	return time.Duration(minutes) * time.Minute, nil
 }
 
 // facetFields maps supported facet field names to their OpenSearch field.
 // Text fields with a .keyword subfield use the subfield for exact aggregation;
 // enum fields are stored as keyword type and are queried directly.
 var facetFields = map[string]string{
+	"eventType":            "eventType",
 	"gameSystem":           "gameSystem.keyword",
 	"group":                "group.keyword",
 	"location":             "location.keyword",
@@ -202,14 +501,125 @@ var facetFields = map[string]string{
 	"experienceRequired":   "experienceRequired",
 	"attendeeRegistration": "attendeeRegistration",
 	"specialCategory":      "specialCategory",
+	"day":                  "day",
+	"bggId":                "bggId",
+}
+
+// defaultFacetSize is the number of values returned per facet when no size is requested.
+const defaultFacetSize = 100
+
+// parseSearchFacets parses a comma-separated list of facet fields for a search request.
+func parseSearchFacets(s string) ([]event.FacetRequest, error) {
+	tokens := strings.Split(s, ",")
+	facets := make([]event.FacetRequest, 0, len(tokens))
+	seen := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+
+		osField, ok := facetFields[token]
+		if !ok {
+			return nil, fmt.Errorf("unsupported facet field [%s]", token)
+		}
+
+		field, err := event.FieldFromString(token)
+		if err != nil {
+			return nil, err
+		}
+
+		facets = append(facets, event.FacetRequest{
+			Field:   field,
+			OSField: osField,
+			Size:    defaultFacetSize,
+		})
+	}
+
+	if len(facets) == 0 {
+		return nil, fmt.Errorf("at least 1 facet field is required")
+	}
+
+	return facets, nil
+}
+
+// histogramField describes a numeric or date field supported by histogram facets.
+type histogramField struct {
+	field           event.Field
+	defaultInterval string
+}
+
+// histogramFields maps supported histogram facet names to their field and default bucket interval.
+var histogramFields = map[string]histogramField{
+	"cost":             {field: event.Cost, defaultInterval: "5"},
+	"duration":         {field: event.Duration, defaultInterval: "1"},
+	"ticketsAvailable": {field: event.TicketsAvailable, defaultInterval: "5"},
+	"bggAvgRating":     {field: event.BggAvgRating, defaultInterval: "1"},
+	"bggRank":          {field: event.BggRank, defaultInterval: "100"},
+	"startDateTime":    {field: event.StartDateTime, defaultInterval: "day"},
+	"startHour":        {field: event.StartHour, defaultInterval: "1"},
+	"endHour":          {field: event.EndHour, defaultInterval: "1"},
+}
+
+// facetReservedParams are facet query parameters that are not search filters.
+var facetReservedParams = map[string]struct{}{
+	"size":     {},
+	"interval": {},
+	"ranges":   {},
+}
+
+// facetUnsupportedParams are search query parameters that are not search terms, so they cannot filter facets
+var facetUnsupportedParams = map[string]struct{}{
+	"excludeConflictsWith": {},
+	"conflictBuffer":       {},
+}
+
+// facetFilterTerms builds the search terms for a facet request from its query parameters.
+// The facet's own field is skipped so its counts are not narrowed by its own filter,
+// and soft-deleted events are always excluded.
+func facetFilterTerms(req *restful.Request, facetField string) ([]search.Term, error) {
+	var terms []search.Term
+	for queryParam, values := range req.Request.URL.Query() {
+		if _, ok := facetReservedParams[queryParam]; ok || queryParam == facetField {
+			continue
+		}
+
+		if _, ok := facetUnsupportedParams[queryParam]; ok {
+			return nil, fmt.Errorf("search query param %s is not supported by facets", queryParam)
+		}
+
+		term, err := event.NewSearchField(queryParam, strings.Join(values, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid search query param %s: %w", queryParam, err)
+		}
+
+		terms = append(terms, term)
+	}
+
+	visibleSearchTerm, err := event.NewSearchField(string(event.Deleted), "false")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the visibility filter: %w", err)
+	}
+
+	return append(terms, visibleSearchTerm), nil
 }
 
 // Facets handles GET /api/events/facets/{field}
 func (e *EventHandler) Facets(req *restful.Request, resp *restful.Response) {
 	const maxSize = 5000
-	size := 100
+	size := defaultFacetSize
 
 	fieldParam := req.PathParameter("field")
+	if hf, ok := histogramFields[fieldParam]; ok {
+		e.histogramFacets(req, resp, fieldParam, hf)
+		return
+	}
+
 	osField, ok := facetFields[fieldParam]
 	if !ok {
 		resp.WriteHeader(http.StatusNotFound)
@@ -232,7 +642,15 @@ func (e *EventHandler) Facets(req *restful.Request, resp *restful.Response) {
 		size = parsed
 	}
 
-	facets, err := e.manager.GetKeywordFacets(req.Request.Context(), osField, size)
+	terms, err := facetFilterTerms(req, fieldParam)
+	if err != nil {
+		body, _ := json.Marshal(gcbapi.KeywordFacetsResponse{Error: err.Error()})
+		resp.WriteHeader(http.StatusBadRequest)
+		resp.Write(body)
+		return
+	}
+
+	facets, err := e.manager.GetKeywordFacets(req.Request.Context(), osField, size, terms...)
 	if err != nil {
 		e.logger.Err(err).Msgf("failed to get %s facets", fieldParam)
 		body, _ := json.Marshal(gcbapi.KeywordFacetsResponse{Error: fmt.Sprintf("failed to retrieve %s facets", fieldParam)})
@@ -252,3 +670,680 @@ func (e *EventHandler) Facets(req *restful.Request, resp *restful.Response) {
 	resp.WriteHeader(http.StatusOK)
 	resp.Write(body)
 }
+
+// histogramFacets handles GET /api/events/facets/{field} for numeric and date fields
+func (e *EventHandler) histogramFacets(req *restful.Request, resp *restful.Response, fieldParam string, hf histogramField) {
+	var response gcbapi.HistogramFacetsResponse
+
+	defer func() {
+		body, err := json.Marshal(response)
+		if err != nil {
+			e.logger.Err(err).Msgf("failed to marshal %s histogram facets response", fieldParam)
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		resp.Write(body)
+	}()
+
+	histogramReq := event.HistogramFacetRequest{
+		Field:    hf.field,
+		Interval: hf.defaultInterval,
+	}
+
+	if interval := req.QueryParameter("interval"); interval != "" {
+		histogramReq.Interval = interval
+	}
+
+	if rangesParam := req.QueryParameter("ranges"); rangesParam != "" {
+		if event.IsDateField(hf.field) {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("ranges are not supported for the date field %s, use interval", fieldParam)
+			return
+		}
+
+		values, err := search.NewGenericValue(rangesParam)
+		if err != nil {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("invalid ranges param: %s", err)
+			return
+		}
+
+		for _, v := range values {
+			rng, ok := v.(search.Range)
+			if !ok {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("invalid ranges param, every value must be a range but got %v", v)
+				return
+			}
+
+			histogramReq.Ranges = append(histogramReq.Ranges, rng)
+		}
+	} else {
+		response.Interval = histogramReq.Interval
+	}
+
+	terms, err := facetFilterTerms(req, fieldParam)
+	if err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = err.Error()
+		return
+	}
+	histogramReq.Terms = terms
+
+	buckets, err := e.manager.GetHistogramFacets(req.Request.Context(), histogramReq)
+	if err != nil {
+		e.logger.Err(err).Msgf("failed to get %s histogram facets", fieldParam)
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = fmt.Sprintf("failed to retrieve %s facets", fieldParam)
+		return
+	}
+
+	response.Buckets = buckets
+	resp.WriteHeader(http.StatusOK)
+}
+
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
+)
+
+// Suggest handles GET /api/events/suggest
+func (e *EventHandler) Suggest(req *restful.Request, resp *restful.Response) {
+	var (
+		response gcbapi.SuggestResponse
+		query    string
+		limit    = defaultSuggestLimit
+	)
+
+	defer func() {
+		body, err := json.Marshal(response)
+		if err != nil {
+			e.logger.Err(err).Msg("failed to marshal suggest response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		resp.Write(body)
+	}()
+
+	for queryParam, values := range req.Request.URL.Query() {
+		switch queryParam {
+		case "q":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = "only 1 q query parameter is allowed"
+				return
+			}
+
+			query = strings.TrimSpace(values[0])
+		case "limit":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = "only 1 limit query parameter is allowed"
+				return
+			}
+
+			i, err := strconv.Atoi(values[0])
+			if err != nil || i < 1 || i > maxSuggestLimit {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("limit must be an integer between 1 and %d", maxSuggestLimit)
+				return
+			}
+
+			limit = i
+		default:
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("unsupported query paramter supplied [%s]", queryParam)
+			return
+		}
+	}
+
+	if query == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "suggest requires a q query param"
+		return
+	}
+
+	suggestions, err := e.manager.Suggest(req.Request.Context(), query, limit)
+	if err != nil {
+		e.logger.Err(err).Str("query", query).Msg("failed to suggest completions")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to suggest completions"
+		return
+	}
+
+	response.Suggestions = suggestions
+	resp.WriteHeader(http.StatusOK)
+}
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 100
+)
+
+// Similar handles GET /api/events/{id}/similar
+func (e *EventHandler) Similar(req *restful.Request, resp *restful.Response) {
+	var (
+		response    gcbapi.SimilarEventsResponse
+		similarReq  = event.SimilarRequest{Limit: defaultSimilarLimit}
+		overlapping bool
+	)
+
+	defer func() {
+		body, err := json.Marshal(response)
+		if err != nil {
+			e.logger.Err(err).Msg("failed to marshal similar events response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		resp.Write(body)
+	}()
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	if id == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "similar events requires an id"
+		return
+	}
+
+	for queryParam, values := range req.Request.URL.Query() {
+		if len(values) > 1 {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("only 1 %s query parameter is allowed", queryParam)
+			return
+		}
+
+		var err error
+		switch queryParam {
+		case "limit":
+			similarReq.Limit, err = strconv.Atoi(values[0])
+			if err != nil || similarReq.Limit < 1 || similarReq.Limit > maxSimilarLimit {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("limit must be an integer between 1 and %d", maxSimilarLimit)
+				return
+			}
+		case "includeSoldOut":
+			similarReq.IncludeSoldOut, err = strconv.ParseBool(values[0])
+		case "overlapping":
+			overlapping, err = strconv.ParseBool(values[0])
+		case "start":
+			similarReq.WindowStart, err = time.Parse(time.RFC3339, values[0])
+		case "end":
+			similarReq.WindowEnd, err = time.Parse(time.RFC3339, values[0])
+		default:
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("unsupported query paramter supplied [%s]", queryParam)
+			return
+		}
+
+		if err != nil {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("invalid %s query parameter: %s", queryParam, err)
+			return
+		}
+	}
+
+	if overlapping && (!similarReq.WindowStart.IsZero() || !similarReq.WindowEnd.IsZero()) {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "overlapping cannot be combined with start or end"
+		return
+	}
+
+	if !similarReq.WindowStart.IsZero() && !similarReq.WindowEnd.IsZero() && !similarReq.WindowEnd.After(similarReq.WindowStart) {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "end must be after start"
+		return
+	}
+
+	similar, found, err := e.manager.Similar(req.Request.Context(), id, similarReq, overlapping)
+	if err != nil {
+		e.logger.Err(err).Str("event_id", id).Msg("failed to find similar events")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to find similar events"
+		return
+	}
+
+	if !found {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("event [%s] not found", id)
+		return
+	}
+
+	response.Events = similar
+	resp.WriteHeader(http.StatusOK)
+}
+
+const (
+	defaultTicketHistoryLimit = 500
+	maxTicketHistoryLimit     = 5000
+)
+
+// TicketHistory handles GET /api/events/{id}/tickets/history
+func (e *EventHandler) TicketHistory(req *restful.Request, resp *restful.Response) {
+	var (
+		response gcbapi.TicketHistoryResponse
+		limit    = defaultTicketHistoryLimit
+	)
+
+	defer func() {
+		body, err := json.Marshal(response)
+		if err != nil {
+			e.logger.Err(err).Msg("failed to marshal ticket history response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		resp.Write(body)
+	}()
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	if id == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "ticket history requires an id"
+		return
+	}
+
+	for queryParam, values := range req.Request.URL.Query() {
+		if len(values) > 1 {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("only 1 %s query parameter is allowed", queryParam)
+			return
+		}
+
+		switch queryParam {
+		case "limit":
+			var err error
+			limit, err = strconv.Atoi(values[0])
+			if err != nil || limit < 1 || limit > maxTicketHistoryLimit {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("limit must be an integer between 1 and %d", maxTicketHistoryLimit)
+				return
+			}
+		default:
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("unsupported query paramter supplied [%s]", queryParam)
+			return
+		}
+	}
+
+	history, found, err := e.manager.TicketHistory(req.Request.Context(), id, limit)
+	if err != nil {
+		e.logger.Err(err).Str("event_id", id).Msg("failed to fetch the ticket history")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to fetch ticket history"
+		return
+	}
+
+	if !found {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("event [%s] not found", id)
+		return
+	}
+
+	response.History = &history
+	resp.WriteHeader(http.StatusOK)
+}
+
+const (
+	defaultEventHistoryLimit = 500
+	maxEventHistoryLimit     = 5000
+)
+
+// EventHistory handles GET /api/events/{id}/history
+func (e *EventHandler) EventHistory(req *restful.Request, resp *restful.Response) {
+	var (
+		response gcbapi.EventHistoryResponse
+		limit    = defaultEventHistoryLimit
+	)
+
+	defer func() {
+		body, err := json.Marshal(response)
+		if err != nil {
+			e.logger.Err(err).Msg("failed to marshal event history response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		resp.Write(body)
+	}()
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	if id == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "event history requires an id"
+		return
+	}
+
+	for queryParam, values := range req.Request.URL.Query() {
+		if len(values) > 1 {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("only 1 %s query parameter is allowed", queryParam)
+			return
+		}
+
+		switch queryParam {
+		case "limit":
+			var err error
+			limit, err = strconv.Atoi(values[0])
+			if err != nil || limit < 1 || limit > maxEventHistoryLimit {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("limit must be an integer between 1 and %d", maxEventHistoryLimit)
+				return
+			}
+		default:
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("unsupported query paramter supplied [%s]", queryParam)
+			return
+		}
+	}
+
+	history, found, err := e.manager.EventHistory(req.Request.Context(), id, limit)
+	if err != nil {
+		e.logger.Err(err).Str("event_id", id).Msg("failed to fetch the event history")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to fetch event history"
+		return
+	}
+
+	if !found {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("event [%s] not found", id)
+		return
+	}
+
+	response.History = &history
+	resp.WriteHeader(http.StatusOK)
+}
+
+const calendarContentType = "text/calendar"
+
+// CalendarEvents handles GET /api/events/calendar.ics
+func (e *EventHandler) CalendarEvents(req *restful.Request, resp *restful.Response) {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, value := range req.Request.URL.Query()["ids"] {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+
+			if _, ok := seen[id]; ok {
+				continue
+			}
+
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		resp.WriteErrorString(http.StatusBadRequest, "calendar export requires at least 1 id")
+		return
+	}
+
+	if len(ids) > maxFetchIDs {
+		resp.WriteErrorString(http.StatusBadRequest, fmt.Sprintf("cannot export more than %d events at once, got %d", maxFetchIDs, len(ids)))
+		return
+	}
+
+	events, _, err := e.manager.FetchEvents(req.Request.Context(), ids...)
+	if err != nil {
+		e.logger.Err(err).Msgf("failed to fetch %d events for a calendar export", len(ids))
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to fetch events")
+		return
+	}
+
+	e.writeCalendar(resp, events)
+}
+
+// CalendarSearch handles GET /api/events/search.ics
+// Soft-deleted events are kept in the search, so they can be written as cancelled.
+func (e *EventHandler) CalendarSearch(req *restful.Request, resp *restful.Response) {
+	query := req.Request.URL.Query()
+
+	for _, param := range exportUnsupportedParams {
+		if query.Has(param) {
+			resp.WriteErrorString(http.StatusBadRequest, fmt.Sprintf("%s is not supported when exporting, every matching event is exported", param))
+			return
+		}
+	}
+
+	searchReq, _, err := e.parseSearchRequest(query, true)
+	if err != nil {
+		resp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := e.manager.SearchAll(req.Request.Context(), searchReq)
+	if err != nil {
+		e.logger.Err(err).Msgf("Failed to perform calendar search request [%+v]", searchReq)
+		resp.WriteErrorString(http.StatusInternalServerError, "failed executing search request")
+		return
+	}
+
+	e.writeCalendar(resp, events)
+}
+
+func (e *EventHandler) writeCalendar(resp *restful.Response, events []gcbapi.Event) {
+	var buff bytes.Buffer
+	if err := calendar.Write(&buff, events, time.Now()); err != nil {
+		e.logger.Err(err).Msg("failed to render calendar")
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+		return
+	}
+
+	resp.Header().Set("Content-Type", calendarContentType+"; charset=utf-8")
+	resp.Header().Set("Content-Disposition", `attachment; filename="events.ics"`)
+	resp.WriteHeader(http.StatusOK)
+
+	if _, err := resp.Write(buff.Bytes()); err != nil {
+		e.logger.Err(err).Msg("failed to write calendar response")
+	}
+}
+
+const (
+	csvContentType  = "text/csv"
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// exportUnsupportedParams are search query parameters that do not apply to an export of every matching event
+var exportUnsupportedParams = []string{"limit", "page", "cursor", "facets", "highlight"}
+
+// Export handles GET /api/events/export
+func (e *EventHandler) Export(req *restful.Request, resp *restful.Response) {
+	query := req.Request.URL.Query()
+
+	format := "csv"
+	if values, ok := query["format"]; ok {
+		if len(values) > 1 {
+			resp.WriteErrorString(http.StatusBadRequest, "only 1 format query parameter is allowed")
+			return
+		}
+
+		format = values[0]
+		query.Del("format")
+	}
+
+	for _, param := range exportUnsupportedParams {
+		if query.Has(param) {
+			resp.WriteErrorString(http.StatusBadRequest, fmt.Sprintf("%s is not supported when exporting, every matching event is exported", param))
+			return
+		}
+	}
+
+	searchReq, _, err := e.parseSearchRequest(query, false)
+	if err != nil {
+		resp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var (
+		writer event.Writer
+		out    = &exportResponse{resp: resp, filename: "events." + format}
+	)
+
+	switch format {
+	case "csv":
+		out.contentType = csvContentType + "; charset=windows-1252"
+		writer, err = event.NewCSVWriter(out)
+	case "xlsx":
+		out.contentType = xlsxContentType
+		writer, err = event.NewXLSXWriter(out)
+	default:
+		resp.WriteErrorString(http.StatusBadRequest, fmt.Sprintf("unsupported export format [%s], use csv or xlsx", format))
+		return
+	}
+
+	if err != nil {
+		e.logger.Err(err).Str("format", format).Msg("failed to create the export writer")
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+		return
+	}
+
+	// the status is sent with the first write, so failures after it can only be logged
+	count, err := e.manager.Export(req.Request.Context(), searchReq, writer)
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		e.logger.Err(err).Msgf("failed to export events after %d events [%+v]", count, searchReq)
+		if !out.written {
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to export events")
+		}
+	}
+}
+
+// exportResponse sets the export file headers with the first write, so a failure before
+// anything is written can still be answered with an error status
+type exportResponse struct {
+	resp        *restful.Response
+	contentType string
+	filename    string
+	written     bool
+}
+
+func (w *exportResponse) Write(p []byte) (int, error) {
+	if !w.written {
+		w.resp.Header().Set("Content-Type", w.contentType)
+		w.resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.filename))
+		w.written = true
+	}
+
+	return w.resp.Write(p)
+}
+
+// maxFetchIDs caps how many events can be requested in a single fetch call.
+const maxFetchIDs = 1000
+
+// FetchEvent handles GET /api/events/{id}
+func (e *EventHandler) FetchEvent(req *restful.Request, resp *restful.Response) {
+	var response gcbapi.EventFetchResponse
+
+	defer func() {
+		e.writeFetchResponse(resp, response)
+	}()
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	if id == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "fetch event requires an id"
+		return
+	}
+
+	events, missing, err := e.manager.FetchEvents(req.Request.Context(), id)
+	if err != nil {
+		e.logger.Err(err).Str("event_id", id).Msg("failed to fetch event")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to fetch event"
+		return
+	}
+
+	response.Events = events
+	response.Missing = missing
+
+	if len(events) == 0 {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("event [%s] not found", id)
+		return
+	}
+
+	resp.WriteHeader(http.StatusOK)
+}
+
+// FetchEvents handles POST /api/events/fetch
+func (e *EventHandler) FetchEvents(req *restful.Request, resp *restful.Response) {
+	var (
+		response gcbapi.EventFetchResponse
+		fetchReq gcbapi.EventFetchRequest
+	)
+
+	defer func() {
+		e.writeFetchResponse(resp, response)
+	}()
+
+	if err := json.NewDecoder(req.Request.Body).Decode(&fetchReq); err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = fmt.Sprintf("invalid fetch request body: %s", err)
+		return
+	}
+
+	ids := make([]string, 0, len(fetchReq.IDs))
+	seen := make(map[string]struct{}, len(fetchReq.IDs))
+	for _, id := range fetchReq.IDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "fetch events requires at least 1 id"
+		return
+	}
+
+	if len(ids) > maxFetchIDs {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = fmt.Sprintf("cannot fetch more than %d events at once, got %d", maxFetchIDs, len(ids))
+		return
+	}
+
+	events, missing, err := e.manager.FetchEvents(req.Request.Context(), ids...)
+	if err != nil {
+		e.logger.Err(err).Msgf("failed to fetch %d events", len(ids))
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to fetch events"
+		return
+	}
+
+	response.Events = events
+	response.Missing = missing
+	resp.WriteHeader(http.StatusOK)
+}
+
+func (e *EventHandler) writeFetchResponse(resp *restful.Response, response gcbapi.EventFetchResponse) {
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		e.logger.Err(err).Msg("failed to marshal event fetch response")
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+		return
+	}
+
+	_, err = resp.Write(responseBody)
+	if err != nil {
+		e.logger.Err(err).Msg("failed to write rest response")
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+		return
+	}
+}
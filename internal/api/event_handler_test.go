@@ -0,0 +1,295 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+func TestParseSearchRequest_CursorFilters(t *testing.T) {
+	cursors, err := event.NewCursorSigner("secret")
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	handler := &EventHandler{logger: &logger, cursors: cursors}
+
+	// the cursor the first page of this search would return
+	first, _, err := handler.parseSearchRequest(url.Values{"eventType": {"RPG"}, "cost": {"[0,4]"}}, false)
+	require.NoError(t, err)
+
+	filters, err := first.FilterHash()
+	require.NoError(t, err)
+
+	token, err := cursors.Encode(event.Cursor{Kind: event.SearchCursor, SearchAfter: []byte(`[1722531600000,"RPG25ND286543"]`), Filters: filters})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		query   url.Values
+		wantErr bool
+	}{
+		{
+			name:  "same filters",
+			query: url.Values{"cost": {"[0,4]"}, "eventType": {"RPG"}, "cursor": {token}, "limit": {"50"}},
+		},
+		{
+			name:    "different filter value",
+			query:   url.Values{"eventType": {"BGM"}, "cost": {"[0,4]"}, "cursor": {token}},
+			wantErr: true,
+		},
+		{
+			name:    "added filter",
+			query:   url.Values{"eventType": {"RPG"}, "cost": {"[0,4]"}, "filter": {"dragon"}, "cursor": {token}},
+			wantErr: true,
+		},
+		{
+			name:    "dropped filter",
+			query:   url.Values{"eventType": {"RPG"}, "cursor": {token}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, usingCursor, err := handler.parseSearchRequest(tt.query, false)
+			if tt.wantErr {
+				require.ErrorContains(t, err, "same filters")
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, usingCursor)
+		})
+	}
+}
+
+func TestFetchEvents(t *testing.T) {
+	logger := zerolog.Nop()
+	handler := NewEventHandler(&logger, newFetchTestManager(t, map[string]*event.Event{
+		"RPG25ND000001": {GameID: "RPG25ND000001"},
+		"BGM25ND000002": {GameID: "BGM25ND000002"},
+		"BGM25ND000003": {GameID: "BGM25ND000003", Deleted: true},
+	}), nil)
+
+	tooMany := make([]string, maxFetchIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("RPG25ND%06d", i)
+	}
+
+	tests := []struct {
+		name        string
+		ids         []string
+		wantStatus  int
+		wantIDs     []string
+		wantDeleted []string
+		wantMissing []string
+		wantErr     string
+	}{
+		{
+			name:       "preserves the requested order",
+			ids:        []string{"BGM25ND000002", "RPG25ND000001"},
+			wantStatus: http.StatusOK,
+			wantIDs:    []string{"BGM25ND000002", "RPG25ND000001"},
+		},
+		{
+			name:       "dedupes and trims ids",
+			ids:        []string{"RPG25ND000001", " BGM25ND000002 ", "RPG25ND000001", ""},
+			wantStatus: http.StatusOK,
+			wantIDs:    []string{"RPG25ND000001", "BGM25ND000002"},
+		},
+		{
+			name:        "missing ids",
+			ids:         []string{"RPG25ND999999", "RPG25ND000001"},
+			wantStatus:  http.StatusOK,
+			wantIDs:     []string{"RPG25ND000001"},
+			wantMissing: []string{"RPG25ND999999"},
+		},
+		{
+			name:        "deleted events are flagged",
+			ids:         []string{"BGM25ND000003", "RPG25ND000001"},
+			wantStatus:  http.StatusOK,
+			wantIDs:     []string{"BGM25ND000003", "RPG25ND000001"},
+			wantDeleted: []string{"BGM25ND000003"},
+		},
+		{
+			name:       "at the id cap",
+			ids:        append(tooMany[:maxFetchIDs:maxFetchIDs], "RPG25ND000000"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "over the id cap",
+			ids:        tooMany,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    fmt.Sprintf("cannot fetch more than %d events", maxFetchIDs),
+		},
+		{
+			name:       "no ids",
+			ids:        []string{" "},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    "requires at least 1 id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(gcbapi.EventFetchRequest{IDs: tt.ids})
+			require.NoError(t, err)
+
+			httpReq := httptest.NewRequest(http.MethodPost, "/api/events/fetch", strings.NewReader(string(body)))
+			recorder := httptest.NewRecorder()
+			handler.FetchEvents(restful.NewRequest(httpReq), restful.NewResponse(recorder))
+
+			require.Equal(t, tt.wantStatus, recorder.Code)
+
+			var got gcbapi.EventFetchResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Contains(t, got.Error, tt.wantErr)
+
+			if tt.wantIDs == nil {
+				return
+			}
+
+			var gotIDs, gotDeleted []string
+			for _, e := range got.Events {
+				gotIDs = append(gotIDs, e.ID)
+				if e.Attributes.Deleted {
+					gotDeleted = append(gotDeleted, e.ID)
+				}
+			}
+
+			require.Equal(t, tt.wantIDs, gotIDs)
+			require.Equal(t, tt.wantDeleted, gotDeleted)
+			require.Equal(t, tt.wantMissing, got.Missing)
+		})
+	}
+}
+
+func TestFetchEvent(t *testing.T) {
+	logger := zerolog.Nop()
+	handler := NewEventHandler(&logger, newFetchTestManager(t, map[string]*event.Event{
+		"RPG25ND000001": {GameID: "RPG25ND000001"},
+		"BGM25ND000003": {GameID: "BGM25ND000003", Deleted: true},
+	}), nil)
+
+	tests := []struct {
+		name        string
+		id          string
+		wantStatus  int
+		wantDeleted bool
+		wantErr     string
+	}{
+		{name: "found", id: "RPG25ND000001", wantStatus: http.StatusOK},
+		{name: "deleted is flagged", id: "BGM25ND000003", wantStatus: http.StatusOK, wantDeleted: true},
+		{name: "missing", id: "RPG25ND999999", wantStatus: http.StatusNotFound, wantErr: "not found"},
+		{name: "blank id", id: " ", wantStatus: http.StatusBadRequest, wantErr: "requires an id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/api/events/"+url.PathEscape(tt.id), nil))
+			req.PathParameters()["id"] = tt.id
+			recorder := httptest.NewRecorder()
+			handler.FetchEvent(req, restful.NewResponse(recorder))
+
+			require.Equal(t, tt.wantStatus, recorder.Code)
+
+			var got gcbapi.EventFetchResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Contains(t, got.Error, tt.wantErr)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			require.Len(t, got.Events, 1)
+			require.Equal(t, tt.id, got.Events[0].ID)
+			require.Equal(t, tt.wantDeleted, got.Events[0].Attributes.Deleted)
+		})
+	}
+}
+
+func TestCalendarSearch_DeletedEventsAreCancelled(t *testing.T) {
+	start := time.Date(2025, 7, 31, 18, 0, 0, 0, time.UTC)
+
+	var searches []map[string]any
+	manager := newSearchTestManager(t, []*event.Event{
+		{GameID: "RPG25ND000001", Title: "Dragon Quest", StartDateTime: start, EndDateTime: start.Add(4 * time.Hour)},
+		{GameID: "RPG25ND000002", Title: "Cancelled Quest", StartDateTime: start, EndDateTime: start.Add(4 * time.Hour), Deleted: true},
+	}, &searches)
+
+	logger := zerolog.Nop()
+	handler := NewEventHandler(&logger, manager, nil)
+
+	req := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/api/events/search.ics?eventType=RPG", nil))
+	recorder := httptest.NewRecorder()
+	handler.CalendarSearch(req, restful.NewResponse(recorder))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "STATUS:CONFIRMED")
+	require.Contains(t, recorder.Body.String(), "STATUS:CANCELLED")
+
+	require.Len(t, searches, 1)
+	query, err := json.Marshal(searches[0]["query"])
+	require.NoError(t, err)
+	require.NotContains(t, string(query), string(event.Deleted), "soft-deleted events are not filtered out")
+}
+
+func TestExport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"failed"}`, http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	failing := NewEventHandler(&logger, NewEventManager(&logger, event.NewEventRepo(&logger, client, 100, "events"), nil, nil), nil)
+
+	var searches []map[string]any
+	working := NewEventHandler(&logger, newSearchTestManager(t, []*event.Event{{GameID: "RPG25ND000001"}}, &searches), nil)
+
+	tests := []struct {
+		name       string
+		handler    *EventHandler
+		format     string
+		wantStatus int
+	}{
+		{name: "csv search fails", handler: failing, format: "csv", wantStatus: http.StatusInternalServerError},
+		{name: "xlsx search fails", handler: failing, format: "xlsx", wantStatus: http.StatusInternalServerError},
+		{name: "csv", handler: working, format: "csv", wantStatus: http.StatusOK},
+		{name: "xlsx", handler: working, format: "xlsx", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/api/events/export?format="+tt.format, nil))
+			recorder := httptest.NewRecorder()
+			tt.handler.Export(req, restful.NewResponse(recorder))
+
+			require.Equal(t, tt.wantStatus, recorder.Code)
+
+			disposition := recorder.Header().Get("Content-Disposition")
+			if tt.wantStatus != http.StatusOK {
+				require.Empty(t, disposition, "a failed export is not an attachment")
+				return
+			}
+
+			require.Equal(t, `attachment; filename="events.`+tt.format+`"`, disposition)
+			require.NotEmpty(t, recorder.Body.Bytes())
+		})
+	}
+}
@@ -2,52 +2,364 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/rs/zerolog"
 
 	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/changelog"
 	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/search"
+	"github.com/gencon_buddy_api/internal/tickets"
 )
 
 // EventManager handles the inbetween of internal event interactions and external event shapes
 type EventManager struct {
-	logger *zerolog.Logger
-	repo   *event.EventRepo
+	logger        *zerolog.Logger
+	repo          *event.EventRepo
+	ticketRepo    *tickets.Repo
+	changeLogRepo *changelog.Repo
 }
 
 // NewEventManager instantiates a new EventManager
-func NewEventManager(logger *zerolog.Logger, repo *event.EventRepo) EventManager {
+func NewEventManager(logger *zerolog.Logger, repo *event.EventRepo, ticketRepo *tickets.Repo, changeLogRepo *changelog.Repo) EventManager {
 	return EventManager{
-		logger: logger,
-		repo:   repo,
+		logger:        logger,
+		repo:          repo,
+		ticketRepo:    ticketRepo,
+		changeLogRepo: changeLogRepo,
 	}
 }
 
 // GetKeywordFacets returns distinct values and counts for any keyword field or subfield.
-func (m EventManager) GetKeywordFacets(ctx context.Context, field string, size int) ([]gcbapi.KeywordFacet, error) {
-	facets, err := m.repo.GetKeywordFacets(ctx, field, size)
+// Only events matching every term are counted.
+func (m EventManager) GetKeywordFacets(ctx context.Context, field string, size int, terms ...search.Term) ([]gcbapi.KeywordFacet, error) {
+	facets, err := m.repo.GetKeywordFacets(ctx, field, size, terms...)
 	if err != nil {
 		return nil, err
 	}
 
+	return externalizeKeywordFacets(facets), nil
+}
+
+// GetHistogramFacets returns the bucketed distribution of a numeric or date field.
+func (m EventManager) GetHistogramFacets(ctx context.Context, req event.HistogramFacetRequest) ([]gcbapi.HistogramBucket, error) {
+	buckets, err := m.repo.GetHistogramFacets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]gcbapi.HistogramBucket, len(buckets))
+	for i, b := range buckets {
+		result[i] = gcbapi.HistogramBucket{
+			Key:         b.Key,
+			KeyAsString: b.KeyAsString,
+			From:        b.From,
+			To:          b.To,
+			Count:       b.Count,
+		}
+	}
+	return result, nil
+}
+
+func externalizeKeywordFacets(facets []event.KeywordFacet) []gcbapi.KeywordFacet {
 	result := make([]gcbapi.KeywordFacet, len(facets))
 	for i, f := range facets {
 		result[i] = gcbapi.KeywordFacet{Value: f.Value, Count: f.Count}
 	}
-	return result, nil
+	return result
+}
+
+// SearchResult is the externalized result of an event search.
+type SearchResult struct {
+	Total       int64
+	Events      []gcbapi.Event
+	SearchAfter []byte
+	Facets      map[string][]gcbapi.KeywordFacet
 }
 
 // Search for events given the search request
-func (m EventManager) Search(ctx context.Context, search event.SearchRequest) (int64, []gcbapi.Event, error) {
+func (m EventManager) Search(ctx context.Context, search event.SearchRequest) (SearchResult, error) {
+	search, err := m.withConflictsTerm(ctx, search)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
 	resp, err := m.repo.Search(ctx, search)
 	if err != nil {
-		return 0, nil, err
+		return SearchResult{}, err
 	}
 
 	extEvents := make([]gcbapi.Event, len(resp.Events))
 	for i, evt := range resp.Events {
 		extEvents[i] = evt.Externalize()
+		if highlights, ok := resp.Highlights[evt.GameID]; ok {
+			extEvents[i].Meta = &gcbapi.EventMeta{Highlights: highlights}
+		}
+	}
+
+	var facets map[string][]gcbapi.KeywordFacet
+	if len(resp.Facets) != 0 {
+		facets = make(map[string][]gcbapi.KeywordFacet, len(resp.Facets))
+		for field, values := range resp.Facets {
+			facets[string(field)] = externalizeKeywordFacets(values)
+		}
+	}
+
+	return SearchResult{
+		Total:       resp.TotalEvents,
+		Events:      extEvents,
+		SearchAfter: resp.SearchAfter,
+		Facets:      facets,
+	}, nil
+}
+
+// withConflictsTerm fetches the events the search excludes conflicts with, and adds
+// the term excluding their overlapping events. Missing and deleted events cannot conflict.
+func (m EventManager) withConflictsTerm(ctx context.Context, search event.SearchRequest) (event.SearchRequest, error) {
+	if len(search.ExcludeConflictsWith) == 0 {
+		return search, nil
+	}
+
+	resp, err := m.repo.FetchEvents(ctx, search.ExcludeConflictsWith...)
+	if err != nil {
+		return search, fmt.Errorf("failed to fetch the events to exclude conflicts with: %w", err)
+	}
+
+	events := make([]*event.Event, 0, len(resp.Found))
+	for _, id := range search.ExcludeConflictsWith {
+		if e, ok := resp.Found[id]; ok && e != nil && !e.Deleted {
+			events = append(events, e)
+		}
+	}
+
+	term, err := event.NewConflictsTerm(events, search.ConflictBuffer)
+	if err != nil {
+		return search, err
+	}
+
+	if term != nil {
+		search.Terms = append(slices.Clip(search.Terms), term)
+	}
+
+	return search, nil
+}
+
+// FetchEvents fetches the events for the given ids, preserving the requested order.
+// Soft-deleted events are still returned with their deleted flag set, while
+// ids that do not exist are returned in the missing list.
+func (m EventManager) FetchEvents(ctx context.Context, ids ...string) ([]gcbapi.Event, []string, error) {
+	resp, err := m.repo.FetchEvents(ctx, ids...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		found   = make([]gcbapi.Event, 0, len(resp.Found))
+		missing = make([]string, 0, len(resp.Missing))
+	)
+
+	for _, id := range ids {
+		if e, ok := resp.Found[id]; ok && e != nil {
+			found = append(found, e.Externalize())
+			continue
+		}
+
+		missing = append(missing, id)
+	}
+
+	return found, missing, nil
+}
+
+// Suggest returns ranked search-as-you-type completions for the query.
+func (m EventManager) Suggest(ctx context.Context, query string, size int) ([]gcbapi.Suggestion, error) {
+	suggestions, err := m.repo.Suggest(ctx, query, size)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]gcbapi.Suggestion, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = gcbapi.Suggestion{
+			Value: s.Value,
+			Type:  string(s.Field),
+			Count: s.Count,
+		}
+	}
+	return result, nil
+}
+
+// Similar returns the events most like the event with the given id, which is used as the request's source.
+// When overlapping is set, the results are limited to events overlapping the source event.
+// The returned bool is false when the event does not exist.
+func (m EventManager) Similar(ctx context.Context, id string, req event.SimilarRequest, overlapping bool) ([]gcbapi.Event, bool, error) {
+	resp, err := m.repo.FetchEvents(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch the event to find similar events for: %w", err)
+	}
+
+	source, ok := resp.Found[id]
+	if !ok || source == nil {
+		return nil, false, nil
+	}
+
+	req.Source = source
+	if overlapping {
+		req.WindowStart = source.StartDateTime
+		req.WindowEnd = source.EndDateTime
+	}
+
+	events, err := m.repo.Similar(ctx, req)
+	if err != nil {
+		return nil, true, err
+	}
+
+	result := make([]gcbapi.Event, len(events))
+	for i, evt := range events {
+		result[i] = evt.Externalize()
+	}
+	return result, true, nil
+}
+
+// exportBatchSize is the number of events searched for at a time while exporting
+const exportBatchSize = 1000
+
+// Export writes every event matching the search, paging through them with search_after.
+// The request's page and limit are ignored. Returns the number of events written.
+func (m EventManager) Export(ctx context.Context, search event.SearchRequest, writer event.Writer) (int, error) {
+	return m.searchPages(ctx, search, func(events []*event.Event) error {
+		return writer.WriteEvents(ctx, events)
+	})
+}
+
+// SearchAll returns every event matching the search, paging through them with search_after.
+// The request's page and limit are ignored.
+func (m EventManager) SearchAll(ctx context.Context, search event.SearchRequest) ([]gcbapi.Event, error) {
+	var result []gcbapi.Event
+
+	_, err := m.searchPages(ctx, search, func(events []*event.Event) error {
+		for _, evt := range events {
+			result = append(result, evt.Externalize())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// searchPages calls fn with each page of events matching the search, in exportBatchSize pages
+// following search_after. Returns the number of events passed to fn.
+func (m EventManager) searchPages(ctx context.Context, search event.SearchRequest, fn func([]*event.Event) error) (int, error) {
+	search.Page = 0
+	search.Limit = exportBatchSize
+	search.Facets = nil
+	search.Highlight = false
+
+	search, err := m.withConflictsTerm(ctx, search)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for {
+		resp, err := m.repo.Search(ctx, search)
+		if err != nil {
+			return count, err
+		}
+
+		if err := fn(resp.Events); err != nil {
+			return count, err
+		}
+
+		count += len(resp.Events)
+		if len(resp.Events) < search.Limit || len(resp.SearchAfter) == 0 {
+			return count, nil
+		}
+
+		search.SearchAfter = resp.SearchAfter
+	}
+}
+
+// TicketHistory returns the ticket snapshots of an event, oldest first, with the metrics derived from them.
+// The returned bool is false when the event does not exist.
+func (m EventManager) TicketHistory(ctx context.Context, id string, limit int) (gcbapi.TicketHistory, bool, error) {
+	resp, err := m.repo.FetchEvents(ctx, id)
+	if err != nil {
+		return gcbapi.TicketHistory{}, false, err
+	}
+
+	e, ok := resp.Found[id]
+	if !ok || e == nil {
+		return gcbapi.TicketHistory{}, false, nil
+	}
+
+	history, err := m.ticketRepo.History(ctx, id, limit)
+	if err != nil {
+		return gcbapi.TicketHistory{}, true, err
+	}
+
+	metrics := tickets.Compute(history, e.TicketsAvailable, e.TotalTickets, time.Now())
+
+	result := gcbapi.TicketHistory{
+		GameID:           e.GameID,
+		TicketsAvailable: e.TicketsAvailable,
+		TotalTickets:     e.TotalTickets,
+		Metrics: gcbapi.TicketMetrics{
+			Sold:             metrics.Sold,
+			PercentSold:      metrics.PercentSold,
+			SoldPerHour:      metrics.SoldPerHour,
+			ProjectedSellOut: metrics.ProjectedSellOut,
+		},
+		Snapshots: make([]gcbapi.TicketSnapshot, len(history)),
+	}
+
+	for i, s := range history {
+		result.Snapshots[i] = gcbapi.TicketSnapshot{
+			Date:             s.Date,
+			ChangeLogID:      s.ChangeLogID,
+			TicketsAvailable: s.TicketsAvailable,
+			TotalTickets:     s.TotalTickets,
+		}
+	}
+
+	return result, true, nil
+}
+
+// EventHistory returns the change logs that created, updated, or deleted an event, oldest first.
+// The returned bool is false when the event does not exist.
+func (m EventManager) EventHistory(ctx context.Context, id string, limit int) (gcbapi.EventHistory, bool, error) {
+	resp, err := m.repo.FetchEvents(ctx, id)
+	if err != nil {
+		return gcbapi.EventHistory{}, false, err
+	}
+
+	if e, ok := resp.Found[id]; !ok || e == nil {
+		return gcbapi.EventHistory{}, false, nil
+	}
+
+	history, err := m.changeLogRepo.EventHistory(ctx, id, limit)
+	if err != nil {
+		return gcbapi.EventHistory{}, true, err
+	}
+
+	result := gcbapi.EventHistory{
+		GameID:  id,
+		Entries: make([]gcbapi.EventHistoryEntry, len(history)),
+	}
+
+	for i, h := range history {
+		result.Entries[i] = gcbapi.EventHistoryEntry{
+			ChangeLogID: h.ChangeLogID,
+			Date:        h.Date,
+			Action:      string(h.Action),
+			Changes:     changelog.ExternalizeFieldChanges(h.Fields),
+		}
 	}
 
-	return resp.TotalEvents, extEvents, nil
+	return result, true, nil
 }
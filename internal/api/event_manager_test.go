@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+// newFetchTestManager creates an [EventManager] on a fake OpenSearch that answers
+// mget requests from the given events, keyed by game id
+func newFetchTestManager(t *testing.T, events map[string]*event.Event) EventManager {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type doc struct {
+			ID     string       `json:"_id"`
+			Found  bool         `json:"found"`
+			Source *event.Event `json:"_source,omitempty"`
+		}
+
+		docs := make([]doc, len(body.IDs))
+		for i, id := range body.IDs {
+			e, ok := events[id]
+			docs[i] = doc{ID: id, Found: ok, Source: e}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{"docs": docs}); err != nil {
+			t.Errorf("encode mget response: %v", err)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	return NewEventManager(&logger, event.NewEventRepo(&logger, client, 100, "events"), nil, nil)
+}
+
+func TestEventManager_FetchEvents(t *testing.T) {
+	manager := newFetchTestManager(t, map[string]*event.Event{
+		"RPG25ND000001": {GameID: "RPG25ND000001", Title: "Dragon Quest"},
+		"BGM25ND000002": {GameID: "BGM25ND000002", Title: "Catan"},
+		"BGM25ND000003": {GameID: "BGM25ND000003", Title: "Cancelled Catan", Deleted: true},
+	})
+
+	tests := []struct {
+		name        string
+		ids         []string
+		wantIDs     []string
+		wantDeleted []bool
+		wantMissing []string
+	}{
+		{
+			name:        "preserves the requested order",
+			ids:         []string{"BGM25ND000002", "RPG25ND000001"},
+			wantIDs:     []string{"BGM25ND000002", "RPG25ND000001"},
+			wantDeleted: []bool{false, false},
+			wantMissing: []string{},
+		},
+		{
+			name:        "missing ids",
+			ids:         []string{"RPG25ND000001", "RPG25ND999999", "BGM25ND000002"},
+			wantIDs:     []string{"RPG25ND000001", "BGM25ND000002"},
+			wantDeleted: []bool{false, false},
+			wantMissing: []string{"RPG25ND999999"},
+		},
+		{
+			name:        "deleted events are returned flagged as deleted",
+			ids:         []string{"BGM25ND000003", "RPG25ND000001"},
+			wantIDs:     []string{"BGM25ND000003", "RPG25ND000001"},
+			wantDeleted: []bool{true, false},
+			wantMissing: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, missing, err := manager.FetchEvents(context.Background(), tt.ids...)
+			require.NoError(t, err)
+
+			gotIDs := make([]string, len(events))
+			gotDeleted := make([]bool, len(events))
+			for i, e := range events {
+				gotIDs[i] = e.ID
+				gotDeleted[i] = e.Attributes.Deleted
+			}
+
+			require.Equal(t, tt.wantIDs, gotIDs)
+			require.Equal(t, tt.wantDeleted, gotDeleted)
+			require.Equal(t, tt.wantMissing, missing)
+		})
+	}
+}
+
+// newSearchTestManager creates an [EventManager] on a fake OpenSearch that answers searches
+// with the given events, in order and paged with search_after. The body of each search is
+// appended to searches.
+func newSearchTestManager(t *testing.T, events []*event.Event, searches *[]map[string]any) EventManager {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		*searches = append(*searches, raw)
+
+		size, _ := raw["size"].(float64)
+		start, _ := raw["from"].(float64)
+		if after, ok := raw["search_after"].([]any); ok && len(after) != 0 {
+			last, _ := after[0].(float64)
+			start = last + 1
+		}
+
+		type hit struct {
+			ID     string       `json:"_id"`
+			Source *event.Event `json:"_source"`
+			Sort   []int        `json:"sort"`
+		}
+
+		hits := []hit{}
+		for i := int(start); i < min(int(start+size), len(events)); i++ {
+			hits = append(hits, hit{ID: events[i].GameID, Source: events[i], Sort: []int{i}})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"hits": map[string]any{"total": map[string]any{"value": len(events)}, "hits": hits},
+		}); err != nil {
+			t.Errorf("encode search response: %v", err)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	return NewEventManager(&logger, event.NewEventRepo(&logger, client, 100, "events"), nil, nil)
+}
+
+func TestEventManager_SearchAll(t *testing.T) {
+	const total = 2500
+
+	stored := make([]*event.Event, total)
+	for i := range stored {
+		stored[i] = &event.Event{GameID: fmt.Sprintf("RPG25ND%06d", i)}
+	}
+
+	var searches []map[string]any
+	manager := newSearchTestManager(t, stored, &searches)
+
+	events, err := manager.SearchAll(context.Background(), event.SearchRequest{Limit: 10, Page: 3})
+	require.NoError(t, err)
+	require.Len(t, events, total, "the page and limit are ignored")
+	require.Len(t, searches, 3)
+
+	for i, e := range events {
+		require.Equal(t, fmt.Sprintf("RPG25ND%06d", i), e.ID)
+	}
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/search"
+)
+
+// maxSearchGroupDepth is how deeply search groups can be nested in a structured search
+const maxSearchGroupDepth = 8
+
+// SearchBody handles POST /api/events/search
+func (e *EventHandler) SearchBody(req *restful.Request, resp *restful.Response) {
+	var (
+		response gcbapi.EventSearchResponse
+		query    gcbapi.EventSearchQuery
+	)
+
+	defer func() {
+		responseBody, err := json.Marshal(response)
+		if err != nil {
+			e.logger.Err(err).Msg("failed to marshal event search response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		_, err = resp.Write(responseBody)
+		if err != nil {
+			e.logger.Err(err).Msg("failed to write response by")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+	}()
+
+	decoder := json.NewDecoder(req.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&query); err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = &gcbapi.Error{
+			Status: "bad request",
+			Detail: fmt.Sprintf("invalid search body: %s", err),
+		}
+		return
+	}
+
+	searchReq, usingCursor, err := e.parseSearchBody(query)
+	if err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = &gcbapi.Error{
+			Status: "bad request",
+			Detail: err.Error(),
+		}
+		return
+	}
+
+	response = e.search(req, resp, searchReq, usingCursor)
+
+	// the pagination links are GET urls, which cannot carry the body
+	response.Links = gcbapi.Links{Self: req.Request.URL.RequestURI()}
+}
+
+// parseSearchBody builds the event search from a structured search body,
+// the same way [EventHandler.parseSearchRequest] does from query parameters.
+func (e *EventHandler) parseSearchBody(query gcbapi.EventSearchQuery) (event.SearchRequest, bool, error) {
+	searchReq := event.SearchRequest{
+		Page:      0,
+		Limit:     100,
+		Highlight: query.Highlight,
+	}
+
+	if query.Limit != nil {
+		searchReq.Limit = *query.Limit
+	}
+
+	if query.Page != nil {
+		searchReq.Page = *query.Page
+	}
+
+	if len(query.Facets) > 0 {
+		facets, err := parseSearchFacets(strings.Join(query.Facets, ","))
+		if err != nil {
+			return searchReq, false, fmt.Errorf("invalid facets: %s", err)
+		}
+
+		searchReq.Facets = facets
+	}
+
+	if query.Sort != nil {
+		searchReq.Sorts = make([]event.SortEntry, len(query.Sort))
+		for i, s := range query.Sort {
+			field, dir, err := event.ParseSort(s.Field + "." + s.Dir)
+			if err != nil {
+				return searchReq, false, fmt.Errorf("invalid sort[%d]: %s", i, err)
+			}
+
+			searchReq.Sorts[i] = event.SortEntry{Field: field, Dir: dir}
+		}
+	}
+
+	for i, id := range query.ExcludeConflictsWith {
+		if strings.TrimSpace(id) == "" {
+			return searchReq, false, fmt.Errorf("invalid excludeConflictsWith[%d]: game ids cannot be empty", i)
+		}
+
+		searchReq.ExcludeConflictsWith = append(searchReq.ExcludeConflictsWith, strings.TrimSpace(id))
+	}
+
+	if query.ConflictBuffer != nil {
+		buffer, err := conflictBuffer(*query.ConflictBuffer)
+		if err != nil {
+			return searchReq, false, fmt.Errorf("invalid conflictBuffer: %s", err)
+		}
+
+		searchReq.ConflictBuffer = buffer
+	}
+
+	if query.Where != nil {
+		terms, err := compileTopSearchGroup(*query.Where)
+		if err != nil {
+			return searchReq, false, err
+		}
+
+		searchReq.Terms = terms
+	}
+
+	return e.finishSearchRequest(searchReq, query.Page != nil, query.Cursor, false)
+}
+
+// compileTopSearchGroup compiles the where group of a search body. When its conditions are ANDed,
+// each is its own term tagged with its field, like the search query params, so facets skip
+// the conditions on their own field.
+func compileTopSearchGroup(g gcbapi.SearchGroup) ([]search.Term, error) {
+	if g.Op != "" && g.Op != "and" {
+		term, err := compileSearchGroup(g, "where", 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return []search.Term{term}, nil
+	}
+
+	terms := make([]search.Term, 0, len(g.Conditions)+len(g.Groups))
+	for i, c := range g.Conditions {
+		term, err := compileSearchCondition(c)
+		if err != nil {
+			return nil, fmt.Errorf("where.conditions[%d]: %w", i, err)
+		}
+
+		terms = append(terms, event.FieldTerm{Term: term, Field: event.Field(c.Field)})
+	}
+
+	for i, group := range g.Groups {
+		term, err := compileSearchGroup(group, fmt.Sprintf("where.groups[%d]", i), 2)
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// compileSearchGroup compiles a group into a single [search.Bool].
+// path locates the group in the body for errors.
+func compileSearchGroup(g gcbapi.SearchGroup, path string, depth int) (search.Term, error) {
+	if depth > maxSearchGroupDepth {
+		return nil, fmt.Errorf("%s: groups cannot be nested more than %d deep", path, maxSearchGroupDepth)
+	}
+
+	if len(g.Conditions) == 0 && len(g.Groups) == 0 {
+		return nil, fmt.Errorf("%s: group needs at least 1 condition or group", path)
+	}
+
+	terms := make([]search.Term, 0, len(g.Conditions)+len(g.Groups))
+	for i, c := range g.Conditions {
+		term, err := compileSearchCondition(c)
+		if err != nil {
+			return nil, fmt.Errorf("%s.conditions[%d]: %w", path, i, err)
+		}
+
+		terms = append(terms, term)
+	}
+
+	for i, group := range g.Groups {
+		term, err := compileSearchGroup(group, fmt.Sprintf("%s.groups[%d]", path, i), depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+	}
+
+	switch g.Op {
+	case "", "and":
+		return search.NewBool().Must(terms...), nil
+	case "or":
+		return search.NewBool().Should(terms...), nil
+	case "not":
+		return search.NewBool().MustNot(terms...), nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported group op [%s], expected and, or, or not", path, g.Op)
+	}
+}
+
+// compileSearchCondition compiles a condition with the same search terms as the field's query param
+func compileSearchCondition(c gcbapi.SearchCondition) (search.Term, error) {
+	if c.Field == "" {
+		return nil, fmt.Errorf("condition requires a field")
+	}
+
+	op := event.Operator(c.Op)
+	if op == "" {
+		op = event.Equals
+	}
+	opName := op
+
+	var (
+		value string
+		err   error
+	)
+
+	switch op {
+	case "in", "between":
+		if c.Value != nil {
+			return nil, fmt.Errorf("%s takes values, not value", op)
+		}
+
+		values := make([]string, len(c.Values))
+		for i, v := range c.Values {
+			if values[i], err = conditionValue(v); err != nil {
+				return nil, fmt.Errorf("values[%d]: %w", i, err)
+			}
+		}
+
+		if op == "in" {
+			if len(values) == 0 {
+				return nil, fmt.Errorf("in requires at least 1 value")
+			}
+
+			value, op = strings.Join(values, ","), event.Equals
+		} else {
+			if len(values) != 2 {
+				return nil, fmt.Errorf("between requires exactly 2 values, got %d", len(values))
+			}
+
+			value, op = "["+values[0]+","+values[1]+"]", event.Equals
+		}
+	default:
+		if c.Values != nil {
+			return nil, fmt.Errorf("%s takes a value, not values", op)
+		}
+
+		if value, err = conditionValue(c.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	term, err := event.NewComparisonField(c.Field, op, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s condition on %s: %w", opName, c.Field, err)
+	}
+
+	return term, nil
+}
+
+// conditionValue formats a condition value the way the query params take it
+func conditionValue(v any) (string, error) {
+	switch value := v.(type) {
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return "", fmt.Errorf("value cannot be empty")
+		}
+
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case nil:
+		return "", fmt.Errorf("value is required")
+	default:
+		return "", fmt.Errorf("value must be a string, number, or boolean, got %T", v)
+	}
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+func TestParseSearchBody_MatchesQueryParams(t *testing.T) {
+	cursors, err := event.NewCursorSigner("secret")
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	handler := &EventHandler{logger: &logger, cursors: cursors}
+
+	tests := []struct {
+		name  string
+		query string
+		body  string
+	}{
+		{
+			name:  "empty",
+			query: "",
+			body:  `{}`,
+		},
+		{
+			name:  "conditions, sort, paging, and facets",
+			query: "eventType=RPG,BGM&cost=[0,4]&ticketsAvailable=(0,)&sort=startDateTime.asc,title.desc&limit=20&page=2&facets=eventType,location&highlight=true&filter=dragon",
+			body: `{
+				"where": {"conditions": [
+					{"field": "eventType", "op": "in", "values": ["RPG", "BGM"]},
+					{"field": "cost", "op": "between", "values": [0, 4]},
+					{"field": "ticketsAvailable", "op": "gt", "value": 0},
+					{"field": "filter", "value": "dragon"}
+				]},
+				"sort": [{"field": "startDateTime", "dir": "asc"}, {"field": "title", "dir": "desc"}],
+				"limit": 20,
+				"page": 2,
+				"facets": ["eventType", "location"],
+				"highlight": true
+			}`,
+		},
+		{
+			name:  "exclude conflicts",
+			query: "excludeConflictsWith=RPG25ND123456,BGM25ND654321&conflictBuffer=15",
+			body: `{
+				"excludeConflictsWith": ["RPG25ND123456", "BGM25ND654321"],
+				"conflictBuffer": 15
+			}`,
+		},
+		{
+			name:  "query string condition",
+			query: `q=eventType:RPG AND (cost:<=4 OR NOT title:"dungeon crawl")`,
+			body: `{
+				"where": {"conditions": [
+					{"field": "q", "value": "eventType:RPG AND (cost:<=4 OR NOT title:\"dungeon crawl\")"}
+				]}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			want, wantCursor, err := handler.parseSearchRequest(values, false)
+			require.NoError(t, err)
+
+			var body gcbapi.EventSearchQuery
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &body))
+
+			got, gotCursor, err := handler.parseSearchBody(body)
+			require.NoError(t, err)
+
+			require.Equal(t, wantCursor, gotCursor)
+			require.Equal(t, want.Limit, got.Limit)
+			require.Equal(t, want.Page, got.Page)
+			require.Equal(t, want.Sorts, got.Sorts)
+			require.Equal(t, want.Highlight, got.Highlight)
+			require.ElementsMatch(t, want.Facets, got.Facets)
+			require.Equal(t, want.ExcludeConflictsWith, got.ExcludeConflictsWith)
+			require.Equal(t, want.ConflictBuffer, got.ConflictBuffer)
+			require.Equal(t, termQueries(t, want), termQueries(t, got))
+		})
+	}
+}
+
+func TestCompileSearchGroup(t *testing.T) {
+	body := `{
+		"op": "or",
+		"conditions": [{"field": "eventType", "value": "RPG"}],
+		"groups": [{
+			"op": "not",
+			"conditions": [{"field": "title", "op": "phrase", "value": "dungeon crawl"}]
+		}]
+	}`
+
+	var group gcbapi.SearchGroup
+	require.NoError(t, json.Unmarshal([]byte(body), &group))
+
+	got, err := compileSearchGroup(group, "where", 1)
+	require.NoError(t, err)
+
+	want, err := event.ParseQuery(`eventType:RPG OR NOT title:"dungeon crawl"`)
+	require.NoError(t, err)
+
+	gotQuery, err := got.ToQuery()
+	require.NoError(t, err)
+
+	wantQuery, err := want.ToQuery()
+	require.NoError(t, err)
+
+	require.Equal(t, wantQuery, gotQuery)
+}
+
+func TestCompileSearchGroup_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			body:    `{"conditions": [{"field": "bogus", "value": "x"}]}`,
+			wantErr: "where.conditions[0]",
+		},
+		{
+			name:    "unknown op",
+			body:    `{"groups": [{"conditions": [{"field": "cost", "op": "near", "value": 1}]}]}`,
+			wantErr: "where.groups[0].conditions[0]",
+		},
+		{
+			name:    "comparison on a keyword field",
+			body:    `{"conditions": [{"field": "eventType", "op": "lt", "value": "RPG"}]}`,
+			wantErr: "does not support lt",
+		},
+		{
+			name:    "between needs 2 values",
+			body:    `{"conditions": [{"field": "cost", "op": "between", "values": [1]}]}`,
+			wantErr: "exactly 2 values",
+		},
+		{
+			name:    "missing value",
+			body:    `{"conditions": [{"field": "cost"}]}`,
+			wantErr: "value is required",
+		},
+		{
+			name:    "invalid number",
+			body:    `{"conditions": [{"field": "cost", "op": "lte", "value": "cheap"}]}`,
+			wantErr: "where.conditions[0]",
+		},
+		{
+			name:    "empty group",
+			body:    `{"groups": [{"op": "or"}]}`,
+			wantErr: "where.groups[0]: group needs at least 1 condition or group",
+		},
+		{
+			name:    "unknown group op",
+			body:    `{"op": "xor", "conditions": [{"field": "cost", "value": 1}]}`,
+			wantErr: "unsupported group op [xor]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var group gcbapi.SearchGroup
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &group))
+
+			_, err := compileTopSearchGroup(group)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// termQueries are the sorted OpenSearch queries of the search terms, since
+// query params are not parsed in any order
+func termQueries(t *testing.T, req event.SearchRequest) []string {
+	t.Helper()
+
+	queries := make([]string, len(req.Terms))
+	for i, term := range req.Terms {
+		query, err := term.ToQuery()
+		require.NoError(t, err)
+
+		b, err := json.Marshal(query)
+		require.NoError(t, err)
+
+		queries[i] = string(b)
+	}
+
+	slices.Sort(queries)
+	return queries
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+// searchPage describes the page of a search response, used to build the
+// JSON:API pagination links.
+type searchPage struct {
+	page       int
+	limit      int
+	total      int64
+	nextCursor string
+	// usingCursor is true when the current page was requested with a cursor rather than a page number
+	usingCursor bool
+}
+
+// totalPages is the number of pages of limit size needed to hold every matching event.
+func (p searchPage) totalPages() int64 {
+	if p.limit <= 0 {
+		return 0
+	}
+
+	return (p.total + int64(p.limit) - 1) / int64(p.limit)
+}
+
+// paginationLinks builds the JSON:API links for a search request. Every query
+// parameter from the request is kept, only page and cursor are rewritten.
+// Pages that are past the OpenSearch result window link with a cursor instead.
+func paginationLinks(requestURL *url.URL, p searchPage) gcbapi.Links {
+	links := gcbapi.Links{
+		Self: requestURL.RequestURI(),
+	}
+
+	if p.limit <= 0 {
+		return links
+	}
+
+	totalPages := p.totalPages()
+	reachable := func(page int64) bool {
+		return (page+1)*int64(p.limit) <= event.MaxResultWindow
+	}
+
+	links.First = pageLink(requestURL, 0)
+
+	if lastPage := totalPages - 1; lastPage >= 0 && reachable(lastPage) {
+		links.Last = pageLink(requestURL, lastPage)
+	}
+
+	if p.usingCursor {
+		if p.nextCursor != "" {
+			links.Next = cursorLink(requestURL, p.nextCursor)
+		}
+
+		return links
+	}
+
+	if p.page > 0 && int64(p.page) <= totalPages {
+		links.Previous = pageLink(requestURL, int64(p.page-1))
+	}
+
+	if next := int64(p.page + 1); next < totalPages {
+		if reachable(next) {
+			links.Next = pageLink(requestURL, next)
+		} else if p.nextCursor != "" {
+			links.Next = cursorLink(requestURL, p.nextCursor)
+		}
+	}
+
+	return links
+}
+
+func pageLink(requestURL *url.URL, page int64) string {
+	query := requestURL.Query()
+	query.Del("cursor")
+	query.Set("page", strconv.FormatInt(page, 10))
+
+	return linkWithQuery(requestURL, query)
+}
+
+func cursorLink(requestURL *url.URL, cursor string) string {
+	query := requestURL.Query()
+	query.Del("page")
+	query.Set("cursor", cursor)
+
+	return linkWithQuery(requestURL, query)
+}
+
+func linkWithQuery(requestURL *url.URL, query url.Values) string {
+	link := url.URL{
+		Path:     requestURL.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return link.RequestURI()
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+)
+
+func TestPaginationLinks(t *testing.T) {
+	tests := []struct {
+		name       string
+		requestURL string
+		page       searchPage
+		want       gcbapi.Links
+	}{
+		{
+			name:       "first page keeps filters and sorts",
+			requestURL: "/api/events/search?eventType=RPG&sort=cost.asc&limit=10",
+			page:       searchPage{page: 0, limit: 10, total: 35},
+			want: gcbapi.Links{
+				Self: "/api/events/search?eventType=RPG&sort=cost.asc&limit=10",
+				Pagination: gcbapi.Pagination{
+					First: "/api/events/search?eventType=RPG&limit=10&page=0&sort=cost.asc",
+					Last:  "/api/events/search?eventType=RPG&limit=10&page=3&sort=cost.asc",
+					Next:  "/api/events/search?eventType=RPG&limit=10&page=1&sort=cost.asc",
+				},
+			},
+		},
+		{
+			name:       "middle page has previous and next",
+			requestURL: "/api/events/search?limit=10&page=2",
+			page:       searchPage{page: 2, limit: 10, total: 35},
+			want: gcbapi.Links{
+				Self: "/api/events/search?limit=10&page=2",
+				Pagination: gcbapi.Pagination{
+					First:    "/api/events/search?limit=10&page=0",
+					Last:     "/api/events/search?limit=10&page=3",
+					Previous: "/api/events/search?limit=10&page=1",
+					Next:     "/api/events/search?limit=10&page=3",
+				},
+			},
+		},
+		{
+			name:       "last page has no next",
+			requestURL: "/api/events/search?limit=10&page=3",
+			page:       searchPage{page: 3, limit: 10, total: 35},
+			want: gcbapi.Links{
+				Self: "/api/events/search?limit=10&page=3",
+				Pagination: gcbapi.Pagination{
+					First:    "/api/events/search?limit=10&page=0",
+					Last:     "/api/events/search?limit=10&page=3",
+					Previous: "/api/events/search?limit=10&page=2",
+				},
+			},
+		},
+		{
+			name:       "no results only links to the first page",
+			requestURL: "/api/events/search?title=nothing",
+			page:       searchPage{page: 0, limit: 100, total: 0},
+			want: gcbapi.Links{
+				Self: "/api/events/search?title=nothing",
+				Pagination: gcbapi.Pagination{
+					First: "/api/events/search?page=0&title=nothing",
+				},
+			},
+		},
+		{
+			name:       "pages past the result window continue with a cursor",
+			requestURL: "/api/events/search?limit=5000&page=1",
+			page:       searchPage{page: 1, limit: 5000, total: 20000, nextCursor: "abc.def"},
+			want: gcbapi.Links{
+				Self: "/api/events/search?limit=5000&page=1",
+				Pagination: gcbapi.Pagination{
+					First:    "/api/events/search?limit=5000&page=0",
+					Previous: "/api/events/search?limit=5000&page=0",
+					Next:     "/api/events/search?cursor=abc.def&limit=5000",
+				},
+			},
+		},
+		{
+			name:       "cursor requests link to the next cursor",
+			requestURL: "/api/events/search?limit=10&cursor=abc.def",
+			page:       searchPage{limit: 10, total: 35, nextCursor: "ghi.jkl", usingCursor: true},
+			want: gcbapi.Links{
+				Self: "/api/events/search?limit=10&cursor=abc.def",
+				Pagination: gcbapi.Pagination{
+					First: "/api/events/search?limit=10&page=0",
+					Last:  "/api/events/search?limit=10&page=3",
+					Next:  "/api/events/search?cursor=ghi.jkl&limit=10",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.requestURL)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, paginationLinks(u, tt.page))
+		})
+	}
+}
+
+func TestSearchPageTotalPages(t *testing.T) {
+	require.Equal(t, int64(4), searchPage{limit: 10, total: 35}.totalPages())
+	require.Equal(t, int64(1), searchPage{limit: 10, total: 10}.totalPages())
+	require.Equal(t, int64(0), searchPage{limit: 10, total: 0}.totalPages())
+	require.Equal(t, int64(0), searchPage{limit: 0, total: 10}.totalPages())
+}
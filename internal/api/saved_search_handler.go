@@ -0,0 +1,248 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/savedsearch"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+// SavedSearchHandler is the API handler for all /api/saved-searches/* endpoints
+type SavedSearchHandler struct {
+	logger  *zerolog.Logger
+	ws      *restful.WebService
+	manager SavedSearchManager
+}
+
+// NewSavedSearchHandler instantiates a [SavedSearchHandler]
+func NewSavedSearchHandler(logger *zerolog.Logger, manager SavedSearchManager) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		logger:  logger,
+		ws:      new(restful.WebService),
+		manager: manager,
+	}
+}
+
+// Register all saved search endpoints with the restful service
+func (s *SavedSearchHandler) Register() error {
+	if s == nil {
+		return fmt.Errorf("cannot register the saved search endpoints with no SavedSearchHandler")
+	}
+
+	if s.ws == nil {
+		return fmt.Errorf("cannot register the saved search endpoints with no restful.WebService")
+	}
+
+	s.ws.Path("/api/saved-searches")
+	s.ws.Consumes(restful.MIME_JSON)
+	s.ws.Produces(restful.MIME_JSON)
+
+	s.ws.Route(s.ws.POST("").To(s.CreateSavedSearch).
+		Doc("Save an event search. The query accepts the same query parameters as /api/events/search; paging, sorting, facets, and highlights are ignored, and excludeConflictsWith is not supported. " +
+			"After each data update, newly created or updated events matching the search are recorded as notifications.").
+		Reads(gcbapi.CreateSavedSearchRequest{}).
+		Writes(gcbapi.SavedSearchResponse{}))
+
+	s.ws.Route(s.ws.GET("/{id}").To(s.FetchSavedSearch).
+		Doc("Fetch a saved search").
+		Writes(gcbapi.SavedSearchResponse{}).
+		Param(s.ws.PathParameter("id", "The saved search id").
+			DataType("string")))
+
+	s.ws.Route(s.ws.DELETE("/{id}").To(s.DeleteSavedSearch).
+		Doc("Delete a saved search. It stops receiving notifications.").
+		Writes(gcbapi.SavedSearchResponse{}).
+		Param(s.ws.PathParameter("id", "The saved search id").
+			DataType("string")))
+
+	s.ws.Route(s.ws.GET("/{id}/notifications").To(s.ListNotifications).
+		Doc("List the events that matched a saved search after each data update, newest first").
+		Writes(gcbapi.ListSavedSearchNotificationsResponse{}).
+		Param(s.ws.PathParameter("id", "The saved search id").
+			DataType("string")).
+		Param(s.ws.QueryParameter("limit", "The number of notifications to return. Default is 20.").
+			DataType("int").DefaultValue("20").Minimum(1).Maximum(maxNotificationLimit)))
+
+	restful.Add(s.ws)
+
+	return nil
+}
+
+// CreateSavedSearch handles POST /api/saved-searches
+func (s *SavedSearchHandler) CreateSavedSearch(req *restful.Request, resp *restful.Response) {
+	var (
+		response  gcbapi.SavedSearchResponse
+		createReq gcbapi.CreateSavedSearchRequest
+	)
+
+	defer func() {
+		s.writeResponse(resp, response)
+	}()
+
+	if err := json.NewDecoder(req.Request.Body).Decode(&createReq); err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = fmt.Sprintf("invalid saved search request body: %s", err)
+		return
+	}
+
+	savedSearch, err := savedsearch.NewSavedSearch(createReq.Name, createReq.Query)
+	if err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = err.Error()
+		return
+	}
+
+	created, err := s.manager.Create(req.Request.Context(), savedSearch)
+	if err != nil {
+		s.logger.Err(err).Msg("failed to create saved search")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to create saved search"
+		return
+	}
+
+	response.SavedSearch = &created
+	resp.WriteHeader(http.StatusCreated)
+}
+
+// FetchSavedSearch handles GET /api/saved-searches/{id}
+func (s *SavedSearchHandler) FetchSavedSearch(req *restful.Request, resp *restful.Response) {
+	var response gcbapi.SavedSearchResponse
+
+	defer func() {
+		s.writeResponse(resp, response)
+	}()
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	savedSearch, err := s.manager.Get(req.Request.Context(), id)
+	if err != nil {
+		s.logger.Err(err).Str("saved_search_id", id).Msg("failed to fetch saved search")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to fetch saved search"
+		return
+	}
+
+	if savedSearch == nil {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("saved search [%s] not found", id)
+		return
+	}
+
+	response.SavedSearch = savedSearch
+	resp.WriteHeader(http.StatusOK)
+}
+
+// DeleteSavedSearch handles DELETE /api/saved-searches/{id}
+func (s *SavedSearchHandler) DeleteSavedSearch(req *restful.Request, resp *restful.Response) {
+	var response gcbapi.SavedSearchResponse
+
+	defer func() {
+		s.writeResponse(resp, response)
+	}()
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	found, err := s.manager.Delete(req.Request.Context(), id)
+	if err != nil {
+		s.logger.Err(err).Str("saved_search_id", id).Msg("failed to delete saved search")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to delete saved search"
+		return
+	}
+
+	if !found {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("saved search [%s] not found", id)
+		return
+	}
+
+	resp.WriteHeader(http.StatusOK)
+}
+
+// ListNotifications handles GET /api/saved-searches/{id}/notifications
+func (s *SavedSearchHandler) ListNotifications(req *restful.Request, resp *restful.Response) {
+	var (
+		response gcbapi.ListSavedSearchNotificationsResponse
+		limit    = defaultNotificationLimit
+	)
+
+	defer func() {
+		s.writeResponse(resp, response)
+	}()
+
+	for queryParam, values := range req.Request.URL.Query() {
+		switch queryParam {
+		case "limit":
+			if len(values) > 1 {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = "only 1 limit query parameter is allowed"
+				return
+			}
+
+			i, err := strconv.Atoi(values[0])
+			if err != nil || i < 1 || i > maxNotificationLimit {
+				resp.WriteHeader(http.StatusBadRequest)
+				response.Error = fmt.Sprintf("limit must be an integer between 1 and %d", maxNotificationLimit)
+				return
+			}
+
+			limit = i
+		default:
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = fmt.Sprintf("unsupported query paramter supplied [%s]", queryParam)
+			return
+		}
+	}
+
+	id := strings.TrimSpace(req.PathParameter("id"))
+	savedSearch, err := s.manager.Get(req.Request.Context(), id)
+	if err != nil {
+		s.logger.Err(err).Str("saved_search_id", id).Msg("failed to fetch saved search")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to fetch saved search"
+		return
+	}
+
+	if savedSearch == nil {
+		resp.WriteHeader(http.StatusNotFound)
+		response.Error = fmt.Sprintf("saved search [%s] not found", id)
+		return
+	}
+
+	notifications, err := s.manager.ListNotifications(req.Request.Context(), id, limit)
+	if err != nil {
+		s.logger.Err(err).Str("saved_search_id", id).Msg("failed to list saved search notifications")
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to list saved search notifications"
+		return
+	}
+
+	response.Notifications = notifications
+	resp.WriteHeader(http.StatusOK)
+}
+
+func (s *SavedSearchHandler) writeResponse(resp *restful.Response, response any) {
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Err(err).Msg("failed to marshal saved search response")
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+		return
+	}
+
+	_, err = resp.Write(responseBody)
+	if err != nil {
+		s.logger.Err(err).Msg("failed to write rest response")
+		resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+		return
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/savedsearch"
+)
+
+// SavedSearchManager handles the inbetween of internal saved searches and external saved search shapes
+type SavedSearchManager struct {
+	logger *zerolog.Logger
+	repo   *savedsearch.Repo
+}
+
+// NewSavedSearchManager instantiates a new [SavedSearchManager]
+func NewSavedSearchManager(logger *zerolog.Logger, repo *savedsearch.Repo) SavedSearchManager {
+	return SavedSearchManager{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// Create stores the saved search, which must already be validated
+func (m SavedSearchManager) Create(ctx context.Context, s *savedsearch.SavedSearch) (gcbapi.SavedSearch, error) {
+	if err := m.repo.Create(ctx, s); err != nil {
+		return gcbapi.SavedSearch{}, err
+	}
+
+	return externalizeSavedSearch(s), nil
+}
+
+// Get fetches a saved search, returning nil if it does not exist
+func (m SavedSearchManager) Get(ctx context.Context, id string) (*gcbapi.SavedSearch, error) {
+	s, err := m.repo.Get(ctx, id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+
+	ext := externalizeSavedSearch(s)
+	return &ext, nil
+}
+
+// Delete removes a saved search, returning false if it did not exist
+func (m SavedSearchManager) Delete(ctx context.Context, id string) (bool, error) {
+	return m.repo.Delete(ctx, id)
+}
+
+// ListNotifications lists the most recent notifications of a saved search
+func (m SavedSearchManager) ListNotifications(ctx context.Context, id string, limit int) ([]gcbapi.SavedSearchNotification, error) {
+	notifications, err := m.repo.ListNotifications(ctx, savedsearch.ListNotificationsRequest{
+		SavedSearchID: id,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]gcbapi.SavedSearchNotification, len(notifications))
+	for i, n := range notifications {
+		result[i] = gcbapi.SavedSearchNotification{
+			ID:            n.ID,
+			ChangeLogID:   n.ChangeLogID,
+			Date:          n.Date,
+			CreatedEvents: n.CreatedEvents,
+			UpdatedEvents: n.UpdatedEvents,
+		}
+	}
+
+	return result, nil
+}
+
+func externalizeSavedSearch(s *savedsearch.SavedSearch) gcbapi.SavedSearch {
+	return gcbapi.SavedSearch{
+		ID:    s.ID,
+		Name:  s.Name,
+		Query: s.Query,
+		Date:  s.Date,
+	}
+}
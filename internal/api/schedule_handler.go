@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/schedule"
+)
+
+// maxScheduleEvents caps how many events can be analyzed in a single schedule.
+const maxScheduleEvents = 200
+
+// ScheduleHandler is the API handler for all /api/schedule/* endpoints
+type ScheduleHandler struct {
+	logger  *zerolog.Logger
+	ws      *restful.WebService
+	manager ScheduleManager
+}
+
+// NewScheduleHandler instantiates a [ScheduleHandler]
+func NewScheduleHandler(logger *zerolog.Logger, manager ScheduleManager) *ScheduleHandler {
+	return &ScheduleHandler{
+		logger:  logger,
+		ws:      new(restful.WebService),
+		manager: manager,
+	}
+}
+
+// Register all schedule endpoints with the restful service
+func (s *ScheduleHandler) Register() error {
+	if s == nil {
+		return fmt.Errorf("cannot register the schedule endpoints with no ScheduleHandler")
+	}
+
+	if s.ws == nil {
+		return fmt.Errorf("cannot register the schedule endpoints with no restful.WebService")
+	}
+
+	s.ws.Path("/api/schedule")
+	s.ws.Consumes(restful.MIME_JSON)
+	s.ws.Produces(restful.MIME_JSON)
+
+	s.ws.Route(s.ws.POST("/analyze").To(s.Analyze).
+		Doc("Analyze a schedule of events for overlapping times, tight transitions between different locations, " +
+			"deleted or sold out events, and the total ticket cost.").
+		Reads(gcbapi.ScheduleAnalyzeRequest{}).
+		Writes(gcbapi.ScheduleAnalyzeResponse{}))
+
+	restful.Add(s.ws)
+
+	return nil
+}
+
+// Analyze handles POST /api/schedule/analyze
+func (s *ScheduleHandler) Analyze(req *restful.Request, resp *restful.Response) {
+	var (
+		response   gcbapi.ScheduleAnalyzeResponse
+		analyzeReq gcbapi.ScheduleAnalyzeRequest
+	)
+
+	defer func() {
+		responseBody, err := json.Marshal(response)
+		if err != nil {
+			s.logger.Err(err).Msg("failed to marshal schedule analyze response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+
+		_, err = resp.Write(responseBody)
+		if err != nil {
+			s.logger.Err(err).Msg("failed to write rest response")
+			resp.WriteErrorString(http.StatusInternalServerError, "failed to write response")
+			return
+		}
+	}()
+
+	if err := json.NewDecoder(req.Request.Body).Decode(&analyzeReq); err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = fmt.Sprintf("invalid schedule analyze request body: %s", err)
+		return
+	}
+
+	ids := make([]string, 0, len(analyzeReq.GameIDs))
+	seen := make(map[string]struct{}, len(analyzeReq.GameIDs))
+	for _, id := range analyzeReq.GameIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = "schedule analyze requires at least 1 game id"
+		return
+	}
+
+	if len(ids) > maxScheduleEvents {
+		resp.WriteHeader(http.StatusBadRequest)
+		response.Error = fmt.Sprintf("cannot analyze more than %d events at once, got %d", maxScheduleEvents, len(ids))
+		return
+	}
+
+	transitionWindow := schedule.DefaultTransitionWindow
+	if analyzeReq.TransitionMinutes != nil {
+		if *analyzeReq.TransitionMinutes < 0 {
+			resp.WriteHeader(http.StatusBadRequest)
+			response.Error = "transitionMinutes cannot be negative"
+			return
+		}
+
+		transitionWindow = time.Duration(*analyzeReq.TransitionMinutes) * time.Minute
+	}
+
+	result, err := s.manager.Analyze(req.Request.Context(), transitionWindow, ids...)
+	if err != nil {
+		s.logger.Err(err).Msgf("failed to analyze a schedule of %d events", len(ids))
+		resp.WriteHeader(http.StatusInternalServerError)
+		response.Error = "failed to analyze schedule"
+		return
+	}
+
+	response = result
+	resp.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/schedule"
+)
+
+// ScheduleManager handles the inbetween of internal schedule analysis and external schedule shapes
+type ScheduleManager struct {
+	logger    *zerolog.Logger
+	eventRepo *event.EventRepo
+}
+
+// NewScheduleManager instantiates a new [ScheduleManager]
+func NewScheduleManager(logger *zerolog.Logger, eventRepo *event.EventRepo) ScheduleManager {
+	return ScheduleManager{
+		logger:    logger,
+		eventRepo: eventRepo,
+	}
+}
+
+// Analyze fetches the scheduled events and reports their conflicts.
+// Ids that do not exist are returned in the missing list.
+func (m ScheduleManager) Analyze(ctx context.Context, transitionWindow time.Duration, ids ...string) (gcbapi.ScheduleAnalyzeResponse, error) {
+	resp, err := m.eventRepo.FetchEvents(ctx, ids...)
+	if err != nil {
+		return gcbapi.ScheduleAnalyzeResponse{}, err
+	}
+
+	var (
+		events   = make([]*event.Event, 0, len(resp.Found))
+		external = make([]gcbapi.Event, 0, len(resp.Found))
+		missing  []string
+	)
+
+	for _, id := range ids {
+		e, ok := resp.Found[id]
+		if !ok || e == nil {
+			missing = append(missing, id)
+			continue
+		}
+
+		events = append(events, e)
+		external = append(external, e.Externalize())
+	}
+
+	analysis := schedule.Analyze(events, transitionWindow)
+
+	result := gcbapi.ScheduleAnalyzeResponse{
+		Events:      external,
+		Overlaps:    make([]gcbapi.ScheduleOverlap, len(analysis.Overlaps)),
+		Transitions: make([]gcbapi.ScheduleTransition, len(analysis.Transitions)),
+		Unavailable: make([]gcbapi.ScheduleUnavailableEvent, len(analysis.Unavailable)),
+		TotalCost:   analysis.TotalCost,
+		Missing:     missing,
+	}
+
+	for i, o := range analysis.Overlaps {
+		result.Overlaps[i] = gcbapi.ScheduleOverlap{
+			FirstGameID:    o.First.GameID,
+			SecondGameID:   o.Second.GameID,
+			OverlapMinutes: int(o.Duration.Minutes()),
+		}
+	}
+
+	for i, t := range analysis.Transitions {
+		result.Transitions[i] = gcbapi.ScheduleTransition{
+			FromGameID:   t.From.GameID,
+			ToGameID:     t.To.GameID,
+			FromLocation: t.From.Location,
+			ToLocation:   t.To.Location,
+			GapMinutes:   int(t.Gap.Minutes()),
+		}
+	}
+
+	for i, u := range analysis.Unavailable {
+		result.Unavailable[i] = gcbapi.ScheduleUnavailableEvent{
+			GameID: u.Event.GameID,
+			Reason: string(u.Reason),
+		}
+	}
+
+	return result, nil
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/internal/event"
 )
 
 // TestFacetFieldsHaveKeywordSubfields verifies that every facet field that
@@ -38,3 +40,86 @@ func TestFacetFieldsHaveKeywordSubfields(t *testing.T) {
 			"field %q in index template has no .keyword subfield (required by facet %q)", baseField, displayField)
 	}
 }
+
+// TestHistogramFieldsAreNumericOrDate verifies that every histogram facet field
+// is mapped as a numeric or date type in the OpenSearch index template.
+func TestHistogramFieldsAreNumericOrDate(t *testing.T) {
+	raw, err := os.ReadFile("../../cmd/data/initialize/schema/event_index_template.json")
+	require.NoError(t, err)
+
+	var tmpl struct {
+		Mappings struct {
+			Properties map[string]struct {
+				Type string `json:"type"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &tmpl))
+
+	histogramTypes := map[string]struct{}{
+		"integer": {},
+		"double":  {},
+		"date":    {},
+	}
+
+	for displayField, hf := range histogramFields {
+		prop, ok := tmpl.Mappings.Properties[string(hf.field)]
+		require.Truef(t, ok,
+			"index template missing field %q (required by histogram facet %q)", hf.field, displayField)
+		_, ok = histogramTypes[prop.Type]
+		require.Truef(t, ok,
+			"field %q in index template has type %q, histogram facet %q requires a numeric or date type", hf.field, prop.Type, displayField)
+	}
+}
+
+// TestFacetFieldsAreNotVirtual verifies that no keyword or histogram facet is on a
+// virtual field, which only exists as a search param.
+func TestFacetFieldsAreNotVirtual(t *testing.T) {
+	for displayField := range facetFields {
+		require.Falsef(t, event.Field(displayField).IsVirtual(), "facet %q is on a virtual field", displayField)
+	}
+
+	for displayField, hf := range histogramFields {
+		require.Falsef(t, hf.field.IsVirtual(), "histogram facet %q is on a virtual field", displayField)
+	}
+}
+
+// TestSuggestFieldsHaveSuggestSubfields verifies that every suggest field has the
+// edge n-gram .suggest subfield to match on and the .keyword subfield to count on.
+func TestSuggestFieldsHaveSuggestSubfields(t *testing.T) {
+	raw, err := os.ReadFile("../../cmd/data/initialize/schema/event_index_template.json")
+	require.NoError(t, err)
+
+	var tmpl struct {
+		Settings struct {
+			Analysis struct {
+				Analyzer map[string]json.RawMessage `json:"analyzer"`
+			} `json:"analysis"`
+		} `json:"settings"`
+		Mappings struct {
+			Properties map[string]struct {
+				Fields map[string]struct {
+					Analyzer       string `json:"analyzer"`
+					SearchAnalyzer string `json:"search_analyzer"`
+				} `json:"fields"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &tmpl))
+
+	for _, field := range event.SuggestFields {
+		prop, ok := tmpl.Mappings.Properties[string(field)]
+		require.Truef(t, ok, "index template missing suggest field %q", field)
+
+		_, hasKeyword := prop.Fields["keyword"]
+		require.Truef(t, hasKeyword, "suggest field %q has no .keyword subfield", field)
+
+		suggest, hasSuggest := prop.Fields["suggest"]
+		require.Truef(t, hasSuggest, "suggest field %q has no .suggest subfield", field)
+
+		for _, analyzer := range []string{suggest.Analyzer, suggest.SearchAnalyzer} {
+			_, ok := tmpl.Settings.Analysis.Analyzer[analyzer]
+			require.Truef(t, ok, "suggest field %q uses undefined analyzer %q", field, analyzer)
+		}
+	}
+}
@@ -13,18 +13,24 @@ import (
 
 	"github.com/gencon_buddy_api/internal/changelog"
 	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/savedsearch"
+	"github.com/gencon_buddy_api/internal/tickets"
 )
 
 type GenconBuddyAPI struct {
-	logger           *zerolog.Logger
-	eventHandler     *EventHandler
-	changeLogHandler *ChangeLogHandler
-	server           *http.Server
-	eventRepo        *event.EventRepo
-	changeLogRepo    *changelog.Repo
+	logger             *zerolog.Logger
+	eventHandler       *EventHandler
+	changeLogHandler   *ChangeLogHandler
+	changeLogStream    *ChangeLogStream
+	stopStream         context.CancelFunc
+	scheduleHandler    *ScheduleHandler
+	savedSearchHandler *SavedSearchHandler
+	server             *http.Server
+	eventRepo          *event.EventRepo
+	changeLogRepo      *changelog.Repo
 }
 
-func NewGenconBuddyAPI(logger *zerolog.Logger, eventRepo *event.EventRepo, changeLogRepo *changelog.Repo, port int) *GenconBuddyAPI {
+func NewGenconBuddyAPI(logger *zerolog.Logger, eventRepo *event.EventRepo, changeLogRepo *changelog.Repo, savedSearchRepo *savedsearch.Repo, ticketRepo *tickets.Repo, port int, cursorSecret string) (*GenconBuddyAPI, error) {
 
 	gcb := &GenconBuddyAPI{
 		logger: logger,
@@ -33,20 +39,47 @@ func NewGenconBuddyAPI(logger *zerolog.Logger, eventRepo *event.EventRepo, chang
 	logger.Info().Msg("Initializing GenconBuddyAPI")
 
 	logger.Info().Msg("Initializing EventHandler")
-	eventHandler := NewEventHandler(logger, NewEventManager(logger, eventRepo))
+	if cursorSecret == "" {
+		logger.Warn().Msg("No cursor secret configured, search cursors will not survive a restart")
+	}
+
+	cursors, err := event.NewCursorSigner(cursorSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the search cursor signer: %w", err)
+	}
+
+	eventHandler := NewEventHandler(logger, NewEventManager(logger, eventRepo, ticketRepo, changeLogRepo), cursors)
 	eventHandler.Register()
 	gcb.eventHandler = eventHandler
 	logger.Info().Msg("Finidhsed initializing EventHandler")
 
 	logger.Info().Msg("Initializing ChangLogHandler")
 	changeLogManager := NewChangeLogManager(logger, changeLogRepo, eventRepo)
-	changeLogHandler := NewChangeLogHandler(logger, changeLogManager)
+	changeLogStream := NewChangeLogStream(logger, changeLogManager, changeLogStreamInterval)
+	changeLogHandler := NewChangeLogHandler(logger, changeLogManager, changeLogStream, cursors)
 	if err := changeLogHandler.Register(); err != nil {
 		logger.Err(err).Msg("Failed to create the ChangeLogHandler successfully")
 	}
 	gcb.changeLogHandler = changeLogHandler
+	gcb.changeLogStream = changeLogStream
 	logger.Info().Msg("Finished initializing ChangeLogHandler")
 
+	logger.Info().Msg("Initializing ScheduleHandler")
+	scheduleHandler := NewScheduleHandler(logger, NewScheduleManager(logger, eventRepo))
+	if err := scheduleHandler.Register(); err != nil {
+		logger.Err(err).Msg("Failed to create the ScheduleHandler successfully")
+	}
+	gcb.scheduleHandler = scheduleHandler
+	logger.Info().Msg("Finished initializing ScheduleHandler")
+
+	logger.Info().Msg("Initializing SavedSearchHandler")
+	savedSearchHandler := NewSavedSearchHandler(logger, NewSavedSearchManager(logger, savedSearchRepo))
+	if err := savedSearchHandler.Register(); err != nil {
+		logger.Err(err).Msg("Failed to create the SavedSearchHandler successfully")
+	}
+	gcb.savedSearchHandler = savedSearchHandler
+	logger.Info().Msg("Finished initializing SavedSearchHandler")
+
 	logger.Info().Msg("Initializing HTTP Server")
 	logger.Debug().Msgf("Listening to port %d", port)
 	gcb.server = &http.Server{
@@ -60,11 +93,16 @@ func NewGenconBuddyAPI(logger *zerolog.Logger, eventRepo *event.EventRepo, chang
 	gcb.eventRepo = eventRepo
 	gcb.changeLogRepo = changeLogRepo
 
-	return gcb
+	return gcb, nil
 }
 
 // Start starts the GennconBuddyAPI asyncronously
 func (gb *GenconBuddyAPI) Start() {
+	gb.logger.Info().Msg("Starting change log stream")
+	streamCtx, stopStream := context.WithCancel(context.Background())
+	gb.stopStream = stopStream
+	go gb.changeLogStream.Run(streamCtx)
+
 	gb.logger.Info().Msg("Starting http server")
 
 	go func() {
@@ -77,5 +115,10 @@ func (gb *GenconBuddyAPI) Start() {
 
 // Stop attempts to stop the GenconBuddyAPI and returns an error with any issues
 func (gb *GenconBuddyAPI) Stop(ctx context.Context) error {
+	// closes the open streams, otherwise shutdown waits on them until the context is done
+	if gb.stopStream != nil {
+		gb.stopStream()
+	}
+
 	return gb.server.Shutdown(ctx)
 }
@@ -0,0 +1,204 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/convention"
+)
+
+const (
+	// maxLineOctets is the longest a content line can be before it must be folded
+	maxLineOctets = 75
+
+	localTimeFormat = "20060102T150405"
+	utcTimeFormat   = "20060102T150405Z"
+
+	genconEventURL = "https://www.gencon.com/events/%s"
+)
+
+// vTimeZone describes America/Indianapolis, which has followed US daylight saving time since 2006
+var vTimeZone = []string{
+	"BEGIN:VTIMEZONE",
+	"TZID:" + convention.TimeZone,
+	"X-LIC-LOCATION:" + convention.TimeZone,
+	"BEGIN:DAYLIGHT",
+	"TZOFFSETFROM:-0500",
+	"TZOFFSETTO:-0400",
+	"TZNAME:EDT",
+	"DTSTART:20070311T020000",
+	"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU",
+	"END:DAYLIGHT",
+	"BEGIN:STANDARD",
+	"TZOFFSETFROM:-0400",
+	"TZOFFSETTO:-0500",
+	"TZNAME:EST",
+	"DTSTART:20071104T020000",
+	"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU",
+	"END:STANDARD",
+	"END:VTIMEZONE",
+}
+
+// Write renders the events as an RFC 5545 calendar. Each event's UID is derived
+// from its game id, so importing the calendar again updates the existing entries.
+// Soft-deleted events are written as cancelled.
+func Write(w io.Writer, events []gcbapi.Event, now time.Time) error {
+	indy := convention.Location
+
+	bw := bufio.NewWriter(w)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Gen Con Buddy//Gen Con Buddy API//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-TIMEZONE:" + convention.TimeZone,
+	}
+	lines = append(lines, vTimeZone...)
+
+	for _, e := range events {
+		lines = append(lines, vEvent(e.Attributes, indy, now)...)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := bw.WriteString(foldLine(line)); err != nil {
+			return fmt.Errorf("failed to write calendar: %w", err)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// UID is the stable calendar id of an event
+func UID(gameID string) string {
+	return gameID + "@genconbuddy"
+}
+
+// EventURL links to the event on the Gen Con website, or is empty
+// when the game id does not end in the numeric Gen Con event id.
+func EventURL(gameID string) string {
+	i := len(gameID)
+	for i > 0 && gameID[i-1] >= '0' && gameID[i-1] <= '9' {
+		i--
+	}
+
+	if i == len(gameID) {
+		return ""
+	}
+
+	return fmt.Sprintf(genconEventURL, gameID[i:])
+}
+
+func vEvent(e gcbapi.EventAttributes, indy *time.Location, now time.Time) []string {
+	status := "CONFIRMED"
+	if e.Deleted {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + UID(e.GameID),
+		"DTSTAMP:" + now.UTC().Format(utcTimeFormat),
+		"DTSTART;TZID=" + convention.TimeZone + ":" + e.StartDateTime.In(indy).Format(localTimeFormat),
+		"DTEND;TZID=" + convention.TimeZone + ":" + e.EndDateTime.In(indy).Format(localTimeFormat),
+		"SUMMARY:" + escapeText(e.Title),
+		"STATUS:" + status,
+	}
+
+	if !e.LastModified.IsZero() {
+		lines = append(lines, "LAST-MODIFIED:"+e.LastModified.UTC().Format(utcTimeFormat))
+	}
+
+	if location := eventLocation(e); location != "" {
+		lines = append(lines, "LOCATION:"+escapeText(location))
+	}
+
+	url := EventURL(e.GameID)
+	if url != "" {
+		lines = append(lines, "URL:"+url)
+	}
+
+	lines = append(lines,
+		"DESCRIPTION:"+escapeText(eventDescription(e, url)),
+		"END:VEVENT",
+	)
+
+	return lines
+}
+
+func eventLocation(e gcbapi.EventAttributes) string {
+	var parts []string
+	for _, p := range []string{e.Location, e.RoomName} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	if table := strings.TrimSpace(e.TableNumber); table != "" {
+		parts = append(parts, "Table "+table)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func eventDescription(e gcbapi.EventAttributes, url string) string {
+	var parts []string
+	if desc := strings.TrimSpace(e.ShortDescription); desc != "" {
+		parts = append(parts, desc)
+	}
+
+	parts = append(parts, fmt.Sprintf("Cost: $%.2f", e.Cost), "Game ID: "+e.GameID)
+
+	if url != "" {
+		parts = append(parts, url)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+// foldLine splits a content line into CRLF terminated lines of at most 75 octets,
+// continuing each one with a leading space and never splitting a UTF-8 character.
+func foldLine(line string) string {
+	var (
+		sb    strings.Builder
+		limit = maxLineOctets
+	)
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts against the continuation line
+		limit = maxLineOctets - 1
+	}
+
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/convention"
+)
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2025, 7, 31, 18, 0, 0, 0, convention.Location)
+	events := []gcbapi.Event{
+		{
+			ID: "RPG25ND286543",
+			Attributes: gcbapi.EventAttributes{
+				GameID:           "RPG25ND286543",
+				Title:            "Dragons, Dungeons; and More",
+				ShortDescription: "A short\nadventure",
+				StartDateTime:    start.UTC(),
+				EndDateTime:      start.Add(4 * time.Hour).UTC(),
+				Location:         "ICC",
+				RoomName:         "Room 101",
+				TableNumber:      "12",
+				Cost:             4,
+			},
+		},
+		{
+			ID: "BGM25ND000001",
+			Attributes: gcbapi.EventAttributes{
+				GameID:        "BGM25ND000001",
+				Title:         "Cancelled Game",
+				StartDateTime: start,
+				EndDateTime:   start.Add(time.Hour),
+				Deleted:       true,
+			},
+		},
+	}
+
+	var buff bytes.Buffer
+	require.NoError(t, Write(&buff, events, time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)))
+
+	out := buff.String()
+	require.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	require.Contains(t, out, "BEGIN:VTIMEZONE\r\nTZID:America/Indianapolis\r\n")
+	require.Contains(t, out, "UID:RPG25ND286543@genconbuddy\r\n")
+	require.Contains(t, out, "DTSTAMP:20250701T120000Z\r\n")
+	require.Contains(t, out, "DTSTART;TZID=America/Indianapolis:20250731T180000\r\n")
+	require.Contains(t, out, "DTEND;TZID=America/Indianapolis:20250731T220000\r\n")
+	require.Contains(t, out, `SUMMARY:Dragons\, Dungeons\; and More`+"\r\n")
+	require.Contains(t, out, `LOCATION:ICC\, Room 101\, Table 12`+"\r\n")
+	require.Contains(t, out, "URL:https://www.gencon.com/events/286543\r\n")
+	require.Equal(t, 1, strings.Count(out, "STATUS:CANCELLED"))
+	require.Equal(t, 1, strings.Count(out, "STATUS:CONFIRMED"))
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), maxLineOctets)
+	}
+
+	// unfolding restores the full description
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	require.Contains(t, unfolded, `DESCRIPTION:A short\nadventure\nCost: $4.00\nGame ID: RPG25ND286543\nhttps://www.gencon.com/events/286543`+"\r\n")
+}
+
+func TestFoldLine(t *testing.T) {
+	require.Equal(t, "SUMMARY:short\r\n", foldLine("SUMMARY:short"))
+
+	long := "SUMMARY:" + strings.Repeat("é", 50)
+	folded := foldLine(long)
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), maxLineOctets)
+		require.True(t, strings.HasPrefix(line, "SUMMARY:") || strings.HasPrefix(line, " é"))
+	}
+	require.Equal(t, long+"\r\n", strings.ReplaceAll(folded, "\r\n ", ""))
+}
+
+func TestEventURL(t *testing.T) {
+	require.Equal(t, "https://www.gencon.com/events/286543", EventURL("RPG25ND286543"))
+	require.Equal(t, "", EventURL("RPGND"))
+	require.Equal(t, "", EventURL(""))
+}
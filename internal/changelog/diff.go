@@ -0,0 +1,56 @@
+package changelog
+
+import (
+	"strings"
+
+	"github.com/wI2L/jsondiff"
+)
+
+// FieldChange is the old and new value of a single field of an updated event.
+// Old is nil when the field was added and New is nil when it was removed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// EventChange lists the fields that changed on an updated event.
+type EventChange struct {
+	GameID string        `json:"gameId"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// NewEventChange converts the JSON patch between the stored and updated event into per field changes.
+// Events are flat documents, so every operation targets a top level field.
+func NewEventChange(gameID string, patch jsondiff.Patch) EventChange {
+	change := EventChange{
+		GameID: gameID,
+		Fields: make([]FieldChange, 0, len(patch)),
+	}
+
+	for _, op := range patch {
+		change.Fields = append(change.Fields, FieldChange{
+			Field: patchField(op.Path),
+			Old:   op.OldValue,
+			New:   op.Value,
+		})
+	}
+
+	return change
+}
+
+// ChangedFields is the names of every changed field
+func (c EventChange) ChangedFields() []string {
+	fields := make([]string, len(c.Fields))
+	for i, f := range c.Fields {
+		fields[i] = f.Field
+	}
+
+	return fields
+}
+
+// patchField is the top level field of a JSON pointer
+func patchField(path string) string {
+	field, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(field)
+}
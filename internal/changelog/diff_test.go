@@ -0,0 +1,75 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wI2L/jsondiff"
+)
+
+func TestNewEventChange(t *testing.T) {
+	type doc struct {
+		Title    string  `json:"title"`
+		Cost     float64 `json:"cost"`
+		RoomName string  `json:"roomName,omitempty"`
+		Website  string  `json:"website,omitempty"`
+		Path     string  `json:"a/b,omitempty"`
+	}
+
+	testCases := []struct {
+		name   string
+		before doc
+		after  doc
+		want   []FieldChange
+	}{
+		{
+			name:   "no changes",
+			before: doc{Title: "Catan", Cost: 4},
+			after:  doc{Title: "Catan", Cost: 4},
+			want:   []FieldChange{},
+		},
+		{
+			name:   "replaced fields",
+			before: doc{Title: "Catan", Cost: 4, RoomName: "Hall A"},
+			after:  doc{Title: "Catan", Cost: 6, RoomName: "Hall B"},
+			want: []FieldChange{
+				{Field: "cost", Old: float64(4), New: float64(6)},
+				{Field: "roomName", Old: "Hall A", New: "Hall B"},
+			},
+		},
+		{
+			name:   "added and removed fields",
+			before: doc{Title: "Catan", Website: "https://example.com"},
+			after:  doc{Title: "Catan", RoomName: "Hall A"},
+			want: []FieldChange{
+				{Field: "roomName", New: "Hall A"},
+				{Field: "website", Old: "https://example.com"},
+			},
+		},
+		{
+			name:   "escaped field names",
+			before: doc{Path: "old"},
+			after:  doc{Path: "new"},
+			want: []FieldChange{
+				{Field: "a/b", Old: "old", New: "new"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patch, err := jsondiff.Compare(tc.before, tc.after)
+			require.NoError(t, err)
+
+			change := NewEventChange("RPG25ND000001", patch)
+			require.Equal(t, "RPG25ND000001", change.GameID)
+			require.ElementsMatch(t, tc.want, change.Fields)
+
+			fields := make([]string, len(tc.want))
+			for i, f := range tc.want {
+				fields[i] = f.Field
+			}
+			require.ElementsMatch(t, fields, change.ChangedFields())
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+// EntryHydrator fetches change log entries with the events they reference
+type EntryHydrator struct {
+	logger    *zerolog.Logger
+	repo      *Repo
+	eventRepo *event.EventRepo
+}
+
+// NewEntryHydrator instantiates a new [EntryHydrator]
+func NewEntryHydrator(logger *zerolog.Logger, repo *Repo, eventRepo *event.EventRepo) EntryHydrator {
+	return EntryHydrator{
+		logger:    logger,
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+// FetchEntry fetches the desired change log and hydrates the event data.
+// When changedFields is not empty, only the updated events where one of those fields changed are included.
+func (h EntryHydrator) FetchEntry(ctx context.Context, id string, changedFields []string) (gcbapi.ChangeLogEntry, error) {
+	fetchResponse, err := h.repo.FetchEntries(ctx, id)
+	if err != nil {
+		return gcbapi.ChangeLogEntry{}, err
+	}
+
+	if len(fetchResponse.Missing) > 0 {
+		return gcbapi.ChangeLogEntry{}, fmt.Errorf("could not find change log entry [%s]", id)
+	}
+
+	if len(fetchResponse.Found) != 1 {
+		h.logger.Warn().Msgf("expected a single change log entry for id [%s], instead found %d", id, len(fetchResponse.Found))
+	}
+
+	entry, ok := fetchResponse.Found[id]
+	if !ok {
+		return gcbapi.ChangeLogEntry{}, fmt.Errorf("found results for change log entry id [%s], but no entry in result map", id)
+	}
+
+	changes := make(map[string]EventChange, len(entry.Changes))
+	for _, c := range entry.Changes {
+		changes[c.GameID] = c
+	}
+
+	if len(changedFields) > 0 {
+		// entries from before changes were recorded cannot match any field
+		entry.UpdatedEvents = slices.DeleteFunc(entry.UpdatedEvents, func(id string) bool {
+			return !slices.ContainsFunc(changes[id].ChangedFields(), func(f string) bool {
+				return slices.Contains(changedFields, f)
+			})
+		})
+	}
+
+	respEntry := gcbapi.ChangeLogEntry{
+		ID:            id,
+		Date:          entry.Date,
+		UpdatedEvents: make([]gcbapi.Event, 0, len(entry.UpdatedEvents)),
+		DeletedEvents: make([]gcbapi.Event, 0, len(entry.DeletedEvents)),
+		CreatedEvents: make([]gcbapi.Event, 0, len(entry.CreatedEvents)),
+		Changes:       make(map[string][]gcbapi.FieldChange, len(entry.UpdatedEvents)),
+	}
+
+	for _, id := range entry.UpdatedEvents {
+		if c, ok := changes[id]; ok {
+			respEntry.Changes[id] = ExternalizeFieldChanges(c.Fields)
+		}
+	}
+
+	var eventFetchResponse event.FetchEventsResponse
+	if len(entry.CreatedEvents) > 0 {
+		eventFetchResponse, err = h.eventRepo.FetchEvents(ctx, entry.CreatedEvents...)
+		if err != nil {
+			return respEntry, fmt.Errorf("failed to fetch created events for change log [%s]: %w", id, err)
+		}
+
+		for _, id := range entry.CreatedEvents {
+			e, ok := eventFetchResponse.Found[id]
+			if !ok {
+				continue
+			}
+
+			if e == nil {
+				h.logger.Warn().
+					Str("event_id", id).
+					Msg("event repo returned nil for created event, skipping")
+				continue
+			}
+
+			respEntry.CreatedEvents = append(respEntry.CreatedEvents, e.Externalize())
+		}
+	}
+
+	if len(entry.UpdatedEvents) > 0 {
+		eventFetchResponse, err = h.eventRepo.FetchEvents(ctx, entry.UpdatedEvents...)
+		if err != nil {
+			return respEntry, fmt.Errorf("failed to fetch updated events for change log [%s]: %w", id, err)
+		}
+
+		for _, id := range entry.UpdatedEvents {
+			e, ok := eventFetchResponse.Found[id]
+			if !ok {
+				continue
+			}
+
+			if e == nil {
+				h.logger.Warn().
+					Str("event_id", id).
+					Msg("event repo returned nil for updated event, skipping")
+				continue
+			}
+
+			respEntry.UpdatedEvents = append(respEntry.UpdatedEvents, e.Externalize())
+		}
+	}
+
+	if len(entry.DeletedEvents) > 0 {
+		eventFetchResponse, err = h.eventRepo.FetchEvents(ctx, entry.DeletedEvents...)
+		if err != nil {
+			return respEntry, fmt.Errorf("failed to fetch deleted events for change log [%s]: %w", id, err)
+		}
+
+		for _, id := range entry.DeletedEvents {
+			e, ok := eventFetchResponse.Found[id]
+			if !ok {
+				continue
+			}
+
+			if e == nil {
+				h.logger.Warn().
+					Str("event_id", id).
+					Msg("event repo returned nil for deleted event, skipping")
+				continue
+			}
+
+			respEntry.DeletedEvents = append(respEntry.DeletedEvents, e.Externalize())
+		}
+	}
+
+	return respEntry, nil
+}
+
+func ExternalizeFieldChanges(fields []FieldChange) []gcbapi.FieldChange {
+	result := make([]gcbapi.FieldChange, len(fields))
+	for i, f := range fields {
+		result[i] = gcbapi.FieldChange{
+			Field: f.Field,
+			Old:   f.Old,
+			New:   f.New,
+		}
+	}
+
+	return result
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 	"github.com/rs/zerolog"
@@ -161,28 +163,234 @@ func (r *Repo) UpdateEntries(ctx context.Context, entries []*Entry) ([]error, er
 	return r.WriteEntries(ctx, updateAction, entries)
 }
 
-func (r *Repo) List(ctx context.Context, req ListEntriesRequest) ([]*Entry, error) {
+// List fetches a page of the newest entries matching the request. The response's SearchAfter
+// continues to the next page, and is only set when the page is full.
+func (r *Repo) List(ctx context.Context, req ListEntriesRequest) (ListEntriesResponse, error) {
 	r.logger.Debug().Msgf("performing search request: %+v", req)
 	if req.Limit <= 0 {
-		return nil, fmt.Errorf("limit cannot be less than 1, got %d", req.Limit)
+		return ListEntriesResponse{}, fmt.Errorf("limit cannot be less than 1, got %d", req.Limit)
+	}
+
+	if !req.Before.IsZero() && !req.After.IsZero() && !req.Before.After(req.After) {
+		return ListEntriesResponse{}, fmt.Errorf("before must be after the after date")
 	}
 
-	sortField := "date"
-	sortDir := "desc"
+	filter := []any{}
+
+	dateRange := map[string]any{}
+	if !req.Before.IsZero() {
+		dateRange["lt"] = req.Before.Format(time.RFC3339)
+	}
+	if !req.After.IsZero() {
+		dateRange["gt"] = req.After.Format(time.RFC3339)
+	}
+	if len(dateRange) != 0 {
+		filter = append(filter, map[string]any{"range": map[string]any{"date": dateRange}})
+	}
+
+	if req.NonEmpty {
+		// empty arrays are not indexed, so exists only matches entries that changed something
+		filter = append(filter, map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"exists": map[string]any{"field": "createdEvents"}},
+					map[string]any{"exists": map[string]any{"field": "updatedEvents"}},
+					map[string]any{"exists": map[string]any{"field": "deletedEvents"}},
+				},
+				"minimum_should_match": 1,
+			},
+		})
+	}
 
 	searchBody := map[string]any{
 		"track_total_hits": true,
 		"size":             req.Limit,
+		"query": map[string]any{
+			"bool": map[string]any{"filter": filter},
+		},
+		// id breaks ties between entries made in the same second, so search_after never skips one
+		"sort": []any{
+			map[string]any{"date": map[string]any{"order": "desc"}},
+			map[string]any{"id": map[string]any{"order": "desc"}},
+		},
+	}
+
+	if len(req.SearchAfter) != 0 {
+		searchBody["search_after"] = json.RawMessage(req.SearchAfter)
+	}
+
+	resp, err := r.searchEntries(ctx, searchBody)
+	if err != nil {
+		return ListEntriesResponse{}, err
+	}
+
+	if len(resp.Entries) < req.Limit {
+		resp.SearchAfter = nil
+	}
+
+	return resp, nil
+}
+
+// ListAfter fetches the entries created after the AfterID entry, oldest first.
+// Entry ids are UUIDv7s, so they sort in the order the entries were created and
+// the date of the AfterID entry can be recovered from the id.
+func (r *Repo) ListAfter(ctx context.Context, req ListAfterRequest) ([]*Entry, error) {
+	r.logger.Debug().Msgf("performing list after request: %+v", req)
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit cannot be less than 1, got %d", req.Limit)
+	}
+
+	afterID, err := uuid.Parse(req.AfterID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid change log id %q: %w", req.AfterID, err)
+	}
+
+	if afterID.Version() != 7 {
+		return nil, fmt.Errorf("change log id %q is not a UUIDv7", req.AfterID)
+	}
+
+	// entry dates only have second precision, so round down to not miss entries made in the same second
+	sec, nsec := afterID.Time().UnixTime()
+	afterDate := time.Unix(sec, nsec).Truncate(time.Second).UTC()
+
+	searchBody := map[string]any{
+		"size": req.Limit,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"range": map[string]any{"date": map[string]any{"gte": afterDate.Format(time.RFC3339)}}},
+					map[string]any{"range": map[string]any{"id": map[string]any{"gt": afterID.String()}}},
+				},
+			},
+		},
 		"sort": []any{
-			map[string]any{
-				sortField: map[string]any{"order": sortDir},
+			map[string]any{"date": map[string]any{"order": "asc"}},
+			map[string]any{"id": map[string]any{"order": "asc"}},
+		},
+	}
+
+	resp, err := r.searchEntries(ctx, searchBody)
+	return resp.Entries, err
+}
+
+// EventHistory fetches the most recent limit entries that created, updated, or deleted the event,
+// oldest first. Only the parts of each entry about the event are kept.
+func (r *Repo) EventHistory(ctx context.Context, gameID string, limit int) ([]HistoryEntry, error) {
+	r.logger.Debug().Msgf("performing event history request for %s", gameID)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit cannot be less than 1, got %d", limit)
+	}
+
+	actionQuery := func(action Action, field string) map[string]any {
+		return map[string]any{
+			"term": map[string]any{field: map[string]any{"value": gameID, "_name": string(action)}},
+		}
+	}
+
+	searchBody := map[string]any{
+		"size": limit,
+		// the event id lists and changes can hold every event, matched_queries says which list
+		// the event is in and inner_hits carries only the event's own change
+		"_source": map[string]any{
+			"excludes": []string{"createdEvents", "updatedEvents", "deletedEvents", "changes", "eventTypes"},
+		},
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{
+						"bool": map[string]any{
+							"should": []any{
+								actionQuery(ActionCreated, "createdEvents"),
+								actionQuery(ActionUpdated, "updatedEvents"),
+								actionQuery(ActionDeleted, "deletedEvents"),
+							},
+							"minimum_should_match": 1,
+						},
+					},
+				},
+				// the change is optional, entries from before changes were recorded have none
+				"should": []any{
+					map[string]any{
+						"nested": map[string]any{
+							"path":       "changes",
+							"query":      map[string]any{"term": map[string]any{"changes.gameId": gameID}},
+							"inner_hits": map[string]any{"size": 1},
+						},
+					},
+				},
 			},
 		},
+		// newest first so the limit keeps the most recent entries
+		"sort": []any{
+			map[string]any{"date": map[string]any{"order": "desc"}},
+			map[string]any{"id": map[string]any{"order": "desc"}},
+		},
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Entry          *Entry   `json:"_source,omitempty"`
+				MatchedQueries []string `json:"matched_queries,omitempty"`
+				InnerHits      struct {
+					Changes struct {
+						Hits struct {
+							Hits []struct {
+								Change *EventChange `json:"_source,omitempty"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"changes"`
+				} `json:"inner_hits"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := r.search(ctx, searchBody, &response); err != nil {
+		return nil, err
+	}
+
+	hits := response.Hits.Hits
+	history := make([]HistoryEntry, 0, len(hits))
+	for i := len(hits) - 1; i >= 0; i-- {
+		h := hits[i]
+		if h.Entry == nil || len(h.MatchedQueries) == 0 {
+			continue
+		}
+
+		var change *EventChange
+		if changes := h.InnerHits.Changes.Hits.Hits; len(changes) != 0 {
+			change = changes[0].Change
+		}
+
+		history = append(history, newHistoryEntry(h.Entry, Action(h.MatchedQueries[0]), change))
+	}
+
+	return history, nil
+}
+
+func (r *Repo) searchEntries(ctx context.Context, searchBody map[string]any) (ListEntriesResponse, error) {
+	var response entriesearchResponse
+	if err := r.search(ctx, searchBody, &response); err != nil {
+		return ListEntriesResponse{}, err
 	}
 
+	entries := make([]*Entry, len(response.Hits.Hits))
+	for i, e := range response.Hits.Hits {
+		entries[i] = e.Entry
+	}
+
+	resp := ListEntriesResponse{Entries: entries}
+	if len(response.Hits.Hits) != 0 {
+		resp.SearchAfter = response.Hits.Hits[len(response.Hits.Hits)-1].Sort
+	}
+
+	return resp, nil
+}
+
+func (r *Repo) search(ctx context.Context, searchBody map[string]any, response any) error {
 	bodyBytes, err := json.Marshal(searchBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal search request: %w", err)
+		return fmt.Errorf("failed to marshal search request: %w", err)
 	}
 
 	r.logger.Debug().Msgf("Performing search request: %s", bodyBytes)
@@ -194,7 +402,7 @@ func (r *Repo) List(ctx context.Context, req ListEntriesRequest) ([]*Entry, erro
 
 	osResp, err := osReq.Do(ctx, r.client)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() {
 		err := osResp.Body.Close()
@@ -205,30 +413,21 @@ func (r *Repo) List(ctx context.Context, req ListEntriesRequest) ([]*Entry, erro
 
 	if osResp.IsError() {
 		r.logger.Error().Msgf("search request failed. Raw response: %s", osResp.String())
-		return nil, fmt.Errorf("failed search request %d", osResp.StatusCode)
+		return fmt.Errorf("failed search request %d", osResp.StatusCode)
 	}
 
 	r.logger.Debug().Msgf("search request debuging: %s", osResp.String())
 
-	var (
-		response entriesearchResponse
-		buff     = bytes.NewBuffer([]byte{})
-	)
-
+	buff := bytes.NewBuffer([]byte{})
 	if _, err := buff.ReadFrom(osResp.Body); err != nil {
-		return nil, fmt.Errorf("failed to read search response body: %w", err)
+		return fmt.Errorf("failed to read search response body: %w", err)
 	}
 
-	if err := json.Unmarshal(buff.Bytes(), &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal search response: %w", err)
-	}
-
-	entries := make([]*Entry, len(response.Hits.Hits))
-	for i, e := range response.Hits.Hits {
-		entries[i] = e.Entry
+	if err := json.Unmarshal(buff.Bytes(), response); err != nil {
+		return fmt.Errorf("failed to unmarshal search response: %w", err)
 	}
 
-	return entries, nil
+	return nil
 }
 
 func (r *Repo) FetchEntries(ctx context.Context, ids ...string) (FetchEntriesResponse, error) {
@@ -317,6 +516,8 @@ type entriesearchResponse struct {
 			ID    string  `json:"_id"`
 			Score float64 `json:"_score"`
 			Entry *Entry  `json:"_source,omitempty"`
+			// Sort values of the hit, used as search_after for the next page
+			Sort json.RawMessage `json:"sort,omitempty"`
 		} `json:"hits"`
 	} `json:"hits"`
 	Errors bool `json:"errors"`
@@ -0,0 +1,71 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_EventHistory(t *testing.T) {
+	var searchBody map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := json.Unmarshal(body, &searchBody); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// newest first, as sorted by the query
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hits": {"hits": [
+			{
+				"_source": {"id": "3", "date": "2025-08-02T12:00:00Z"},
+				"matched_queries": ["deleted"]
+			},
+			{
+				"_source": {"id": "2", "date": "2025-08-01T12:00:00Z"},
+				"matched_queries": ["updated"],
+				"inner_hits": {"changes": {"hits": {"hits": [
+					{"_source": {"gameId": "RPG1", "fields": [{"field": "cost", "old": 4, "new": 6}]}}
+				]}}}
+			},
+			{
+				"_source": {"id": "1", "date": "2025-07-31T12:00:00Z"},
+				"matched_queries": ["created"],
+				"inner_hits": {"changes": {"hits": {"hits": []}}}
+			}
+		]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+	repo := NewRepo(&logger, client, 100, "change_log")
+
+	history, err := repo.EventHistory(context.Background(), "RPG1", 10)
+	require.NoError(t, err)
+	require.Equal(t, []HistoryEntry{
+		{ChangeLogID: "1", Date: "2025-07-31T12:00:00Z", Action: ActionCreated, Fields: []FieldChange{}},
+		{ChangeLogID: "2", Date: "2025-08-01T12:00:00Z", Action: ActionUpdated, Fields: []FieldChange{{Field: "cost", Old: float64(4), New: float64(6)}}},
+		{ChangeLogID: "3", Date: "2025-08-02T12:00:00Z", Action: ActionDeleted, Fields: []FieldChange{}},
+	}, history)
+
+	source, ok := searchBody["_source"].(map[string]any)
+	require.True(t, ok)
+	require.Contains(t, source["excludes"], "changes", "only the event's change is fetched, through inner hits")
+}
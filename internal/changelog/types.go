@@ -1,9 +1,16 @@
 package changelog
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/gencon_buddy_api/gcbapi"
 )
 
 // Entry - Change Log Entry includes a reference to all of the modified events,
@@ -14,6 +21,18 @@ type Entry struct {
 	UpdatedEvents []string `json:"updatedEvents"`
 	DeletedEvents []string `json:"deletedEvents"`
 	CreatedEvents []string `json:"createdEvents"`
+	// Changes has the changed fields of each updated event
+	Changes []EventChange `json:"changes"`
+	// EventTypes breaks the changed events down by their event type
+	EventTypes []TypeCounts `json:"eventTypes"`
+}
+
+// TypeCounts is how many events of an event type an entry created, updated, and deleted
+type TypeCounts struct {
+	EventType string `json:"eventType"`
+	Created   int    `json:"created"`
+	Updated   int    `json:"updated"`
+	Deleted   int    `json:"deleted"`
 }
 
 // NewEntry instantiates a [Entry] with a UUID for the ID
@@ -25,13 +44,134 @@ func NewEntry() *Entry {
 		UpdatedEvents: []string{},
 		DeletedEvents: []string{},
 		CreatedEvents: []string{},
+		Changes:       []EventChange{},
+		EventTypes:    []TypeCounts{},
 	}
 }
 
+// CountEventTypes sets the EventTypes breakdown from the event type of each changed event,
+// keyed by game id. Changed events missing from eventTypes are left out of the breakdown.
+func (e *Entry) CountEventTypes(eventTypes map[string]string) {
+	counts := make(map[string]*TypeCounts)
+	count := func(ids []string, inc func(*TypeCounts)) {
+		for _, id := range ids {
+			eventType, ok := eventTypes[id]
+			if !ok {
+				continue
+			}
+
+			if _, ok := counts[eventType]; !ok {
+				counts[eventType] = &TypeCounts{EventType: eventType}
+			}
+			inc(counts[eventType])
+		}
+	}
+
+	count(e.CreatedEvents, func(c *TypeCounts) { c.Created++ })
+	count(e.UpdatedEvents, func(c *TypeCounts) { c.Updated++ })
+	count(e.DeletedEvents, func(c *TypeCounts) { c.Deleted++ })
+
+	e.EventTypes = make([]TypeCounts, 0, len(counts))
+	for _, eventType := range slices.Sorted(maps.Keys(counts)) {
+		e.EventTypes = append(e.EventTypes, *counts[eventType])
+	}
+}
+
+// Summarize converts the entry into an api [gcbapi.ChangeLogSummary]
+func (e *Entry) Summarize() gcbapi.ChangeLogSummary {
+	summary := gcbapi.ChangeLogSummary{
+		ID:           e.ID,
+		Date:         e.Date,
+		UpdatedCount: len(e.UpdatedEvents),
+		DeletedCount: len(e.DeletedEvents),
+		CreatedCount: len(e.CreatedEvents),
+		EventTypes:   make([]gcbapi.EventTypeCounts, len(e.EventTypes)),
+	}
+
+	for i, c := range e.EventTypes {
+		summary.EventTypes[i] = gcbapi.EventTypeCounts{
+			EventType:    c.EventType,
+			UpdatedCount: c.Updated,
+			DeletedCount: c.Deleted,
+			CreatedCount: c.Created,
+		}
+	}
+
+	return summary
+}
+
 // ListEntriesRequest fetches a specific number of entries
-// sort by date in ascending order.
+// sorted by date in descending order.
 type ListEntriesRequest struct {
 	Limit int
+	// Before and After only include entries strictly before or after the dates, when not zero
+	Before time.Time
+	After  time.Time
+	// NonEmpty leaves out entries that did not change any events
+	NonEmpty bool
+	// SearchAfter continues from the SearchAfter of a previous [ListEntriesResponse]
+	SearchAfter []byte
+}
+
+// FilterHash identifies the filters of the listing, so a cursor can only continue
+// a listing with the same filters. The limit and search after are not filters.
+func (r ListEntriesRequest) FilterHash() string {
+	filters := fmt.Sprintf("before:%s\nafter:%s\nnonEmpty:%t",
+		r.Before.UTC().Format(time.RFC3339Nano), r.After.UTC().Format(time.RFC3339Nano), r.NonEmpty)
+
+	sum := sha256.Sum256([]byte(filters))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// ListEntriesResponse is a page of entries
+type ListEntriesResponse struct {
+	Entries []*Entry
+	// SearchAfter is the sort values of the last entry, set when there may be more entries
+	SearchAfter []byte
+}
+
+// ListAfterRequest fetches up to Limit entries created after
+// the AfterID entry, sorted by date in ascending order.
+type ListAfterRequest struct {
+	AfterID string
+	Limit   int
+}
+
+// Action is what a change log entry did to an event
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+)
+
+// HistoryEntry is what a single change log entry did to an event
+type HistoryEntry struct {
+	ChangeLogID string
+	Date        string
+	Action      Action
+	// Fields are the changed fields of an update,
+	// empty for entries from before changes were recorded
+	Fields []FieldChange
+}
+
+// newHistoryEntry builds the history of the entry from the event's change, which is nil
+// when the entry did not record one for the event
+func newHistoryEntry(e *Entry, action Action, change *EventChange) HistoryEntry {
+	h := HistoryEntry{
+		ChangeLogID: e.ID,
+		Date:        e.Date,
+		Action:      action,
+		Fields:      []FieldChange{},
+	}
+
+	if action != ActionUpdated || change == nil {
+		return h
+	}
+
+	h.Fields = change.Fields
+	return h
 }
 
 // FetchEntriesResponse contains maps for the found and missing [Entry]
@@ -0,0 +1,150 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/gcbapi"
+)
+
+func TestEntry_CountEventTypes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		entry      Entry
+		eventTypes map[string]string
+		want       []TypeCounts
+	}{
+		{
+			name:       "no changes",
+			entry:      Entry{},
+			eventTypes: map[string]string{"RPG1": "RPG"},
+			want:       []TypeCounts{},
+		},
+		{
+			name: "counts each action by type",
+			entry: Entry{
+				CreatedEvents: []string{"RPG1", "BGM1"},
+				UpdatedEvents: []string{"RPG2", "RPG3"},
+				DeletedEvents: []string{"BGM2"},
+			},
+			eventTypes: map[string]string{
+				"RPG1": "RPG",
+				"RPG2": "RPG",
+				"RPG3": "RPG",
+				"BGM1": "BGM",
+				"BGM2": "BGM",
+			},
+			want: []TypeCounts{
+				{EventType: "BGM", Created: 1, Deleted: 1},
+				{EventType: "RPG", Created: 1, Updated: 2},
+			},
+		},
+		{
+			name: "unknown events are left out",
+			entry: Entry{
+				UpdatedEvents: []string{"RPG1", "NMN1"},
+			},
+			eventTypes: map[string]string{"RPG1": "RPG"},
+			want: []TypeCounts{
+				{EventType: "RPG", Updated: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.entry.CountEventTypes(tc.eventTypes)
+			require.Equal(t, tc.want, tc.entry.EventTypes)
+		})
+	}
+}
+
+func TestEntry_Summarize(t *testing.T) {
+	entry := Entry{
+		ID:            "id",
+		Date:          "2025-07-31T12:00:00Z",
+		CreatedEvents: []string{"RPG1"},
+		UpdatedEvents: []string{"RPG2", "BGM1"},
+		DeletedEvents: []string{},
+		EventTypes: []TypeCounts{
+			{EventType: "BGM", Updated: 1},
+			{EventType: "RPG", Created: 1, Updated: 1},
+		},
+	}
+
+	require.Equal(t, gcbapi.ChangeLogSummary{
+		ID:           "id",
+		Date:         "2025-07-31T12:00:00Z",
+		UpdatedCount: 2,
+		DeletedCount: 0,
+		CreatedCount: 1,
+		EventTypes: []gcbapi.EventTypeCounts{
+			{EventType: "BGM", UpdatedCount: 1},
+			{EventType: "RPG", CreatedCount: 1, UpdatedCount: 1},
+		},
+	}, entry.Summarize())
+}
+
+func TestNewHistoryEntry(t *testing.T) {
+	entry := &Entry{
+		ID:   "id",
+		Date: "2025-07-31T12:00:00Z",
+	}
+
+	change := &EventChange{GameID: "RPG2", Fields: []FieldChange{{Field: "roomName", Old: "Hall A", New: "Hall B"}}}
+
+	testCases := []struct {
+		name   string
+		action Action
+		change *EventChange
+		want   []FieldChange
+	}{
+		{
+			name:   "updated event keeps its changes",
+			action: ActionUpdated,
+			change: change,
+			want:   []FieldChange{{Field: "roomName", Old: "Hall A", New: "Hall B"}},
+		},
+		{
+			name:   "updated event without recorded changes",
+			action: ActionUpdated,
+			change: nil,
+			want:   []FieldChange{},
+		},
+		{
+			name:   "created event has no changes",
+			action: ActionCreated,
+			change: change,
+			want:   []FieldChange{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, HistoryEntry{
+				ChangeLogID: "id",
+				Date:        "2025-07-31T12:00:00Z",
+				Action:      tc.action,
+				Fields:      tc.want,
+			}, newHistoryEntry(entry, tc.action, tc.change))
+		})
+	}
+}
+
+func TestListEntriesRequest_FilterHash(t *testing.T) {
+	after := time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+	req := ListEntriesRequest{After: after, NonEmpty: true}
+
+	same := ListEntriesRequest{After: after.In(time.FixedZone("EDT", -4*60*60)), NonEmpty: true, Limit: 50, SearchAfter: []byte(`[1]`)}
+	require.Equal(t, req.FilterHash(), same.FilterHash(), "the limit, search after, and time zone are not filters")
+
+	for _, other := range []ListEntriesRequest{
+		{After: after},
+		{After: after.Add(time.Second), NonEmpty: true},
+		{Before: after, NonEmpty: true},
+	} {
+		require.NotEqual(t, req.FilterHash(), other.FilterHash())
+	}
+}
@@ -0,0 +1,23 @@
+package convention
+
+import (
+	"time"
+	// embedded so the time zone loads on hosts without a zoneinfo database
+	_ "time/tzdata"
+)
+
+// TimeZone is the time zone Gen Con runs in. Event days and hours, date search terms,
+// date histograms, and calendar times are all in this time zone.
+const TimeZone = "America/Indianapolis"
+
+// Location is the loaded [TimeZone]
+var Location = mustLoadLocation(TimeZone)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return loc
+}
@@ -0,0 +1,91 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorSigner_RoundTrip(t *testing.T) {
+	signer, err := NewCursorSigner("secret")
+	require.NoError(t, err)
+
+	cursor := Cursor{
+		Kind:        SearchCursor,
+		Sorts:       []SortEntry{{Field: Cost, Dir: "desc"}, {Field: Title, Dir: "asc"}},
+		SearchAfter: []byte(`[4,"Dragon Quest","RPG25ND286543"]`),
+		Filters:     "filters",
+	}
+
+	token, err := signer.Encode(cursor)
+	require.NoError(t, err)
+
+	got, err := signer.Decode(token, SearchCursor)
+	require.NoError(t, err)
+	require.Equal(t, cursor.Kind, got.Kind)
+	require.Equal(t, cursor.Sorts, got.Sorts)
+	require.Equal(t, cursor.Filters, got.Filters)
+	require.JSONEq(t, string(cursor.SearchAfter), string(got.SearchAfter))
+}
+
+func TestCursorSigner_DefaultSort(t *testing.T) {
+	signer, err := NewCursorSigner("secret")
+	require.NoError(t, err)
+
+	token, err := signer.Encode(Cursor{Kind: SearchCursor, SearchAfter: []byte(`[1722531600000,"RPG25ND286543"]`)})
+	require.NoError(t, err)
+
+	got, err := signer.Decode(token, SearchCursor)
+	require.NoError(t, err)
+	require.Empty(t, got.Sorts)
+}
+
+func TestCursorSigner_Rejects(t *testing.T) {
+	signer, err := NewCursorSigner("secret")
+	require.NoError(t, err)
+
+	token, err := signer.Encode(Cursor{Kind: SearchCursor, SearchAfter: []byte(`[1]`)})
+	require.NoError(t, err)
+
+	other, err := NewCursorSigner("other secret")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		signer *CursorSigner
+		token  string
+		kind   CursorKind
+	}{
+		{name: "empty token", signer: signer, token: "", kind: SearchCursor},
+		{name: "tampered signature", signer: signer, token: token[:len(token)-1] + "x", kind: SearchCursor},
+		{name: "no separator", signer: signer, token: "abc", kind: SearchCursor},
+		{name: "different secret", signer: other, token: token, kind: SearchCursor},
+		{name: "different kind", signer: signer, token: token, kind: ChangeLogCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.signer.Decode(tt.token, tt.kind)
+			require.Error(t, err)
+		})
+	}
+
+	_, err = signer.Encode(Cursor{Kind: SearchCursor})
+	require.Error(t, err, "a cursor requires search after values")
+
+	_, err = signer.Encode(Cursor{SearchAfter: []byte(`[1]`)})
+	require.Error(t, err, "a cursor requires a kind")
+}
+
+func TestCursorSigner_RandomSecret(t *testing.T) {
+	a, err := NewCursorSigner("")
+	require.NoError(t, err)
+	b, err := NewCursorSigner("")
+	require.NoError(t, err)
+
+	token, err := a.Encode(Cursor{Kind: SearchCursor, SearchAfter: []byte(`[1]`)})
+	require.NoError(t, err)
+
+	_, err = b.Decode(token, SearchCursor)
+	require.Error(t, err)
+}
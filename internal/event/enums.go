@@ -2,6 +2,9 @@ package event
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 )
 
 // AgeGroup is an enum for the limit set of Age Groups allowed for gencon events
@@ -243,3 +246,41 @@ func CategoryFromSearchTerm(s string) Category {
 		return Category("invalid")
 	}
 }
+
+// Weekday is the day an event starts on, in Indy time
+type Weekday string
+
+const (
+	Monday    Weekday = "mon"
+	Tuesday   Weekday = "tue"
+	Wednesday Weekday = "wed"
+	Thursday  Weekday = "thu"
+	Friday    Weekday = "fri"
+	Saturday  Weekday = "sat"
+	Sunday    Weekday = "sun"
+)
+
+// conventionDays are the days in the order they happen at the convention,
+// which runs Wednesday through Sunday
+var conventionDays = []Weekday{Wednesday, Thursday, Friday, Saturday, Sunday, Monday, Tuesday}
+
+// WeekdayFromTime converts a [time.Weekday] to its Weekday
+func WeekdayFromTime(w time.Weekday) Weekday {
+	return Weekday(strings.ToLower(w.String()[:3]))
+}
+
+// WeekdayFromSearchTerm converts an external facing day, ie thu or Thursday, to the
+// enum value for the event stored in the data store.
+func WeekdayFromSearchTerm(s string) Weekday {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) < 3 {
+		return Weekday("invalid")
+	}
+
+	day := Weekday(s[:3])
+	if !slices.Contains(conventionDays, day) {
+		return Weekday("invalid")
+	}
+
+	return day
+}
@@ -1,6 +1,11 @@
 package event
 
-import "github.com/gencon_buddy_api/internal/bgg"
+import (
+	"time"
+
+	"github.com/gencon_buddy_api/internal/bgg"
+	"github.com/gencon_buddy_api/internal/convention"
+)
 
 // Hydrator adds additional information to an event
 type Hydrator interface {
@@ -50,3 +55,39 @@ func (h HydrateBGG) Hydrate(e *Event) error {
 func (h HydrateBGG) Name() string {
 	return "HydrateBGG"
 }
+
+// HydrateConventionTime sets the day, start hour, and end hour of events in Indy time,
+// so events can be searched by the time of day they run.
+type HydrateConventionTime struct{}
+
+// Hydrate ...
+func (h HydrateConventionTime) Hydrate(e *Event) error {
+	if e.StartDateTime.IsZero() {
+		return nil
+	}
+
+	indy := convention.Location
+
+	start := e.StartDateTime.In(indy)
+	e.Day = WeekdayFromTime(start.Weekday())
+	e.StartHour = int64(start.Hour())
+
+	if e.EndDateTime.IsZero() {
+		e.EndHour = e.StartHour
+		return nil
+	}
+
+	// events ending after midnight keep counting up, ie 1am the next day is hour 25
+	end := e.EndDateTime.In(indy)
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, indy)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, indy)
+	days := int64(endDay.Sub(startDay).Round(24*time.Hour) / (24 * time.Hour))
+	e.EndHour = int64(end.Hour()) + 24*days
+
+	return nil
+}
+
+// Name ...
+func (h HydrateConventionTime) Name() string {
+	return "HydrateConventionTime"
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -61,3 +62,61 @@ func TestHydrateBGG_KeySeparator(t *testing.T) {
 func TestHydrateBGG_Name(t *testing.T) {
 	require.Equal(t, "HydrateBGG", NewHydrateBGG(nil).Name())
 }
+
+func TestHydrateConventionTime(t *testing.T) {
+	indy, err := time.LoadLocation("America/Indianapolis")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		start         time.Time
+		end           time.Time
+		wantDay       Weekday
+		wantStartHour int64
+		wantEndHour   int64
+	}{
+		{
+			name:          "evening event",
+			start:         time.Date(2025, 7, 31, 18, 0, 0, 0, indy),
+			end:           time.Date(2025, 7, 31, 22, 0, 0, 0, indy),
+			wantDay:       Thursday,
+			wantStartHour: 18,
+			wantEndHour:   22,
+		},
+		{
+			name:          "event past midnight",
+			start:         time.Date(2025, 8, 1, 22, 0, 0, 0, indy),
+			end:           time.Date(2025, 8, 2, 2, 0, 0, 0, indy),
+			wantDay:       Friday,
+			wantStartHour: 22,
+			wantEndHour:   26,
+		},
+		{
+			name:          "utc times are converted to indy",
+			start:         time.Date(2025, 8, 3, 13, 0, 0, 0, time.UTC),
+			end:           time.Date(2025, 8, 3, 15, 0, 0, 0, time.UTC),
+			wantDay:       Sunday,
+			wantStartHour: 9,
+			wantEndHour:   11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Event{StartDateTime: tt.start, EndDateTime: tt.end}
+
+			require.NoError(t, HydrateConventionTime{}.Hydrate(e))
+			require.Equal(t, tt.wantDay, e.Day)
+			require.Equal(t, tt.wantStartHour, e.StartHour)
+			require.Equal(t, tt.wantEndHour, e.EndHour)
+		})
+	}
+}
+
+func TestHydrateConventionTime_NoStart(t *testing.T) {
+	e := &Event{}
+
+	require.NoError(t, HydrateConventionTime{}.Hydrate(e))
+	require.Empty(t, e.Day)
+	require.Zero(t, e.StartHour)
+}
@@ -0,0 +1,403 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gencon_buddy_api/internal/search"
+)
+
+// QueryError is a problem with a query string, at the 1-based column it was found.
+type QueryError struct {
+	Column int
+	Msg    string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+func queryErrorf(column int, format string, args ...any) *QueryError {
+	return &QueryError{Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// ParseQuery compiles a query string into a [search.Term]. The grammar is
+//
+//	query      = or
+//	or         = and { "OR" and }
+//	and        = not { [ "AND" ] not }
+//	not        = "NOT" not | primary
+//	primary    = "(" or ")" | clause | word | "\"phrase\""
+//	clause     = field ":" [ "<" | "<=" | ">" | ">=" ] value
+//
+// Terms next to each other without an operator are ANDed, and AND binds tighter than OR.
+// The operators are only recognised in upper case. Bare words and phrases search the
+// [Filter] fields. A clause value is anything its field accepts as a query param,
+// including comma lists and ranges such as cost:[1,4]. Quoted clause values match
+// text fields as a phrase. The comparison operators only work on number and date fields.
+//
+// Errors are [*QueryError]s.
+func ParseQuery(q string) (search.Term, error) {
+	tokens, err := lexQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+	if p.peek().kind == queryEOF {
+		return nil, queryErrorf(1, "query is empty")
+	}
+
+	term, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != queryEOF {
+		return nil, queryErrorf(t.column, "unexpected %s", t)
+	}
+
+	return term, nil
+}
+
+type queryTokenKind int
+
+const (
+	queryEOF queryTokenKind = iota
+	queryLParen
+	queryRParen
+	queryAnd
+	queryOr
+	queryNot
+	queryWord
+	queryClause
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	column int
+	// text is the word, or the value of a clause
+	text   string
+	quoted bool
+	// field, op, and valueColumn are only set on clauses
+	field       string
+	op          string
+	valueColumn int
+}
+
+func (t queryToken) String() string {
+	switch t.kind {
+	case queryEOF:
+		return "end of query"
+	case queryLParen:
+		return "("
+	case queryRParen:
+		return ")"
+	case queryAnd:
+		return "AND"
+	case queryOr:
+		return "OR"
+	case queryNot:
+		return "NOT"
+	case queryClause:
+		return fmt.Sprintf("%s:", t.field)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+func lexQuery(q string) ([]queryToken, error) {
+	var (
+		runes  = []rune(q)
+		tokens []queryToken
+		i      int
+	)
+
+	for i < len(runes) {
+		r := runes[i]
+		column := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: queryLParen, column: column})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: queryRParen, column: column})
+			i++
+		case r == '"':
+			phrase, next, err := lexQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, queryToken{kind: queryWord, column: column, text: phrase, quoted: true})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !isQueryDelimiter(runes[i]) && runes[i] != ':' {
+				i++
+			}
+
+			word := string(runes[start:i])
+			if i < len(runes) && runes[i] == ':' {
+				if word == "" {
+					return nil, queryErrorf(column, "expected a field name before :")
+				}
+
+				clause, next, err := lexClauseValue(runes, word, i+1)
+				if err != nil {
+					return nil, err
+				}
+
+				clause.column = column
+				clause.field = word
+				tokens = append(tokens, clause)
+				i = next
+				continue
+			}
+
+			kind := queryWord
+			switch word {
+			case "AND":
+				kind = queryAnd
+			case "OR":
+				kind = queryOr
+			case "NOT":
+				kind = queryNot
+			}
+
+			tokens = append(tokens, queryToken{kind: kind, column: column, text: word})
+		}
+	}
+
+	return append(tokens, queryToken{kind: queryEOF, column: len(runes) + 1}), nil
+}
+
+// lexClauseValue lexes the optional comparison operator and value starting at i,
+// right after the field's colon.
+func lexClauseValue(runes []rune, field string, i int) (queryToken, int, error) {
+	clause := queryToken{kind: queryClause}
+
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(string(runes[i:]), op) {
+			clause.op = op
+			i += len(op)
+			break
+		}
+	}
+
+	clause.valueColumn = i + 1
+	if i >= len(runes) || unicode.IsSpace(runes[i]) || runes[i] == ')' || (clause.op != "" && runes[i] == '(') {
+		return clause, i, queryErrorf(i+1, "expected a value for %s", field)
+	}
+
+	switch {
+	case runes[i] == '"':
+		value, next, err := lexQuoted(runes, i)
+		if err != nil {
+			return clause, i, err
+		}
+
+		clause.text = value
+		clause.quoted = true
+		return clause, next, nil
+	case clause.op == "" && (runes[i] == '[' || runes[i] == '('):
+		// ranges use the same brackets as the query param values
+		end := i + 1
+		for end < len(runes) && runes[end] != ']' && runes[end] != ')' {
+			end++
+		}
+
+		if end >= len(runes) {
+			return clause, i, queryErrorf(i+1, "range is missing a closing ] or )")
+		}
+
+		clause.text = string(runes[i : end+1])
+		return clause, end + 1, nil
+	default:
+		start := i
+		for i < len(runes) && !isQueryDelimiter(runes[i]) {
+			i++
+		}
+
+		clause.text = string(runes[start:i])
+		return clause, i, nil
+	}
+}
+
+// lexQuoted lexes the quoted string starting at the quote at i.
+// A backslash escapes the next character.
+func lexQuoted(runes []rune, i int) (string, int, error) {
+	var (
+		b     strings.Builder
+		start = i
+	)
+
+	for i++; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				b.WriteRune(runes[i])
+			}
+		case '"':
+			if b.Len() == 0 {
+				return "", i, queryErrorf(start+1, "quoted value is empty")
+			}
+
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", i, queryErrorf(start+1, "quote is never closed")
+}
+
+func isQueryDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.pos]
+	if t.kind != queryEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *queryParser) parseOr() (search.Term, error) {
+	term, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := []search.Term{term}
+	for p.peek().kind == queryOr {
+		p.next()
+
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+
+	return search.NewBool().Should(terms...), nil
+}
+
+func (p *queryParser) parseAnd() (search.Term, error) {
+	term, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := []search.Term{term}
+	for {
+		switch p.peek().kind {
+		case queryAnd:
+			p.next()
+		case queryLParen, queryNot, queryWord, queryClause:
+			// terms next to each other are ANDed
+		default:
+			if len(terms) == 1 {
+				return terms[0], nil
+			}
+
+			return search.NewBool().Must(terms...), nil
+		}
+
+		term, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+	}
+}
+
+func (p *queryParser) parseNot() (search.Term, error) {
+	if p.peek().kind != queryNot {
+		return p.parsePrimary()
+	}
+
+	p.next()
+	term, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	return search.NewBool().MustNot(term), nil
+}
+
+func (p *queryParser) parsePrimary() (search.Term, error) {
+	t := p.next()
+
+	switch t.kind {
+	case queryLParen:
+		term, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != queryRParen {
+			return nil, queryErrorf(closing.column, "expected ) to close the ( at column %d, got %s", t.column, closing)
+		}
+
+		return term, nil
+	case queryWord:
+		return FilterTerm{value: t.text}, nil
+	case queryClause:
+		return t.clauseTerm()
+	default:
+		return nil, queryErrorf(t.column, "expected a search term, got %s", t)
+	}
+}
+
+// clauseTerm compiles a field clause with the same search terms as the field's query param
+func (t queryToken) clauseTerm() (search.Term, error) {
+	if field, err := FieldFromString(t.field); err != nil || field == Query {
+		return nil, queryErrorf(t.column, "unsupported search field %s", t.field)
+	}
+
+	op := Equals
+	switch {
+	case t.op != "":
+		op = queryOperators[t.op]
+	case t.quoted:
+		op = Phrase
+	}
+
+	term, err := NewComparisonField(t.field, op, t.text)
+	if err != nil {
+		return nil, queryErrorf(t.valueColumn, "invalid value for %s: %s", t.field, err)
+	}
+
+	return term, nil
+}
+
+// queryOperators maps the query comparison operators to their [Operator]
+var queryOperators = map[string]Operator{
+	"<":  LessThan,
+	"<=": LessThanOrEqual,
+	">":  GreaterThan,
+	">=": GreaterThanOrEqual,
+}
@@ -0,0 +1,160 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/internal/search"
+)
+
+func TestParseQuery(t *testing.T) {
+	term := func(field, value string) search.Term {
+		t.Helper()
+		st, err := NewSearchField(field, value)
+		require.NoError(t, err)
+		return st
+	}
+
+	phrase := func(field, value string) search.Term {
+		t.Helper()
+		st, err := search.NewTextPhrase(field, value)
+		require.NoError(t, err)
+		return st
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  search.Term
+	}{
+		{
+			name:  "single clause",
+			query: "eventType:RPG",
+			want:  term("eventType", "RPG"),
+		},
+		{
+			name:  "bare word searches the filter fields",
+			query: "catan",
+			want:  FilterTerm{value: "catan"},
+		},
+		{
+			name:  "bare phrase searches the filter fields",
+			query: `"ticket to ride"`,
+			want:  FilterTerm{value: "ticket to ride"},
+		},
+		{
+			name:  "quoted text clause is a phrase",
+			query: `title:"dungeon crawl"`,
+			want:  phrase("title", "dungeon crawl"),
+		},
+		{
+			name:  "escaped quote",
+			query: `title:"the \"big\" game"`,
+			want:  phrase("title", `the "big" game`),
+		},
+		{
+			name:  "comparison operators",
+			query: "cost:<=4 minPlayers:>2 maxPlayers:<6 duration:>=1.5",
+			want: search.NewBool().Must(
+				term("cost", "(,4]"),
+				term("minPlayers", "(2,)"),
+				term("maxPlayers", "(,6)"),
+				term("duration", "[1.5,)"),
+			),
+		},
+		{
+			name:  "date comparison",
+			query: "startDateTime:>=2025-07-31T10:00:00-04:00",
+			want:  term("startDateTime", "[2025-07-31T10:00:00-04:00,)"),
+		},
+		{
+			name:  "range and list values",
+			query: "cost:[1,4) eventType:RPG,BGM",
+			want:  search.NewBool().Must(term("cost", "[1,4)"), term("eventType", "RPG,BGM")),
+		},
+		{
+			name:  "AND binds tighter than OR",
+			query: "eventType:RPG AND cost:0 OR eventType:BGM",
+			want: search.NewBool().Should(
+				search.NewBool().Must(term("eventType", "RPG"), term("cost", "0")),
+				term("eventType", "BGM"),
+			),
+		},
+		{
+			name:  "parentheses group",
+			query: "eventType:RPG AND (cost:0 OR cost:2)",
+			want: search.NewBool().Must(
+				term("eventType", "RPG"),
+				search.NewBool().Should(term("cost", "0"), term("cost", "2")),
+			),
+		},
+		{
+			name:  "NOT",
+			query: "NOT eventType:RPG catan",
+			want: search.NewBool().Must(
+				search.NewBool().MustNot(term("eventType", "RPG")),
+				FilterTerm{value: "catan"},
+			),
+		},
+		{
+			name:  "lower case operators are words",
+			query: "war and peace",
+			want: search.NewBool().Must(
+				FilterTerm{value: "war"},
+				FilterTerm{value: "and"},
+				FilterTerm{value: "peace"},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			wantQuery, err := tt.want.ToQuery()
+			require.NoError(t, err)
+
+			gotQuery, err := got.ToQuery()
+			require.NoError(t, err)
+
+			require.Equal(t, wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantColumn int
+	}{
+		{name: "empty", query: "  ", wantColumn: 1},
+		{name: "unknown field", query: "cost:1 bogus:2", wantColumn: 8},
+		{name: "missing value", query: "cost: 4", wantColumn: 6},
+		{name: "missing value at end", query: "cost:<=", wantColumn: 8},
+		{name: "missing field", query: "eventType:RPG :4", wantColumn: 15},
+		{name: "comparison on a keyword field", query: "eventType:>RPG", wantColumn: 12},
+		{name: "comparison against a list", query: "cost:<1,2", wantColumn: 7},
+		{name: "invalid number", query: "cost:<=cheap", wantColumn: 8},
+		{name: "invalid date", query: "startDateTime:>tomorrow", wantColumn: 16},
+		{name: "unclosed quote", query: `title:"dungeon`, wantColumn: 7},
+		{name: "unclosed range", query: "cost:[1,4", wantColumn: 6},
+		{name: "unclosed paren", query: "(cost:1 OR cost:2", wantColumn: 18},
+		{name: "unopened paren", query: "cost:1)", wantColumn: 7},
+		{name: "dangling operator", query: "cost:1 OR", wantColumn: 10},
+		{name: "leading operator", query: "AND cost:1", wantColumn: 1},
+		{name: "nested query", query: "q:catan", wantColumn: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+
+			var queryErr *QueryError
+			require.ErrorAs(t, err, &queryErr)
+			require.Equal(t, tt.wantColumn, queryErr.Column, queryErr.Error())
+		})
+	}
+}
@@ -6,17 +6,27 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 	"github.com/rs/zerolog"
+
+	"github.com/gencon_buddy_api/internal/convention"
+	"github.com/gencon_buddy_api/internal/search"
 )
 
 const (
 	bulkMeta     = `{ "%s": { "_index": "%s", "_id": "%s" } }`
 	createAction = "create"
 	updateAction = "update"
+
+	// MaxResultWindow is the OpenSearch index.max_result_window default.
+	// Offset pagination (page * limit) cannot reach past it; use search_after instead.
+	MaxResultWindow = 10000
 )
 
 // textSortFields are fields stored as OpenSearch `text` type.
@@ -191,55 +201,67 @@ func (r *EventRepo) Search(ctx context.Context, req SearchRequest) (SearchRespon
 		return SearchResponse{}, fmt.Errorf("page must be non negative, got %d", req.Page)
 	}
 
-	sortEntries := make([]any, len(req.Sorts))
-	for i, s := range req.Sorts {
-		fieldName := string(s.Field)
-		if _, isText := textSortFields[s.Field]; isText {
-			fieldName = fieldName + ".keyword"
-		}
-		sortEntries[i] = map[string]any{
-			fieldName: map[string]any{"order": s.Dir},
-		}
-	}
-	if len(sortEntries) == 0 {
-		sortEntries = []any{
-			map[string]any{string(StartDateTime): map[string]any{"order": "asc"}},
-		}
-	}
-
 	searchBody := map[string]any{
 		"track_total_hits": true,
 		"size":             req.Limit,
-		"from":             req.Limit * req.Page,
-		"sort":             sortEntries,
+		"sort":             sortQuery(req.Sorts),
 	}
 
+	// search_after continues from the last hit of the previous page,
+	// so it cannot be combined with an offset.
 	if len(req.SearchAfter) != 0 {
 		searchBody["search_after"] = json.RawMessage(req.SearchAfter)
+	} else {
+		searchBody["from"] = req.Limit * req.Page
 	}
 
-	if len(req.Terms) != 0 {
-		var must []any
-		var termErrors []error
-		for _, t := range req.Terms {
-			query, err := t.ToQuery()
-			if err != nil {
-				termErrors = append(termErrors, err)
-				continue
-			}
+	// Terms on faceted fields are applied as a post_filter, so that each facet
+	// aggregation can apply every filter except its own.
+	var (
+		must         []any
+		facetFilters = make(map[Field][]any)
+		termErrors   []error
+	)
 
-			must = append(must, query)
+	for _, t := range req.Terms {
+		query, err := t.ToQuery()
+		if err != nil {
+			termErrors = append(termErrors, err)
+			continue
 		}
 
-		if len(termErrors) > 0 {
-			return SearchResponse{}, errors.Join(termErrors...)
+		if ft, ok := t.(FieldTerm); ok && hasFacet(req.Facets, ft.Field) {
+			facetFilters[ft.Field] = append(facetFilters[ft.Field], query)
+			continue
 		}
 
+		must = append(must, query)
+	}
+
+	if len(termErrors) > 0 {
+		return SearchResponse{}, errors.Join(termErrors...)
+	}
+
+	if len(must) != 0 {
 		searchBody["query"] = map[string]any{
 			"bool": map[string]any{"must": must},
 		}
 	}
 
+	if postFilter := facetFilterQuery(facetFilters, ""); postFilter != nil {
+		searchBody["post_filter"] = postFilter
+	}
+
+	if len(req.Facets) != 0 {
+		searchBody["aggs"] = facetAggregations(req.Facets, facetFilters)
+	}
+
+	if req.Highlight {
+		if highlight := highlightQuery(req.Terms); highlight != nil {
+			searchBody["highlight"] = highlight
+		}
+	}
+
 	bodyBytes, err := json.Marshal(searchBody)
 	if err != nil {
 		return SearchResponse{}, fmt.Errorf("failed to marshal search request: %w", err)
@@ -293,13 +315,186 @@ func (r *EventRepo) Search(ctx context.Context, req SearchRequest) (SearchRespon
 		Events:      events,
 	}
 
-	if len(req.Sorts) != 0 && len(response.Hits.Hits) != 0 {
+	if req.Highlight {
+		searchResponse.Highlights = make(map[string]map[string][]string)
+		for _, e := range response.Hits.Hits {
+			if len(e.Highlight) != 0 {
+				searchResponse.Highlights[e.ID] = e.Highlight
+			}
+		}
+	}
+
+	if len(response.Hits.Hits) != 0 {
 		searchResponse.SearchAfter = response.Hits.Hits[len(response.Hits.Hits)-1].Sort
 	}
 
+	if len(req.Facets) != 0 {
+		searchResponse.Facets = make(map[Field][]KeywordFacet, len(req.Facets))
+		for _, f := range req.Facets {
+			agg := response.Aggregations[facetAggregationName(f.Field)]
+			searchResponse.Facets[f.Field] = keywordFacetsFromBuckets(agg.Values.Buckets)
+		}
+	}
+
 	return searchResponse, nil
 }
 
+// highlightQuery builds the highlight request for every text field the terms match against.
+// The html encoder escapes the event text in each fragment, so only the <em> tags are markup.
+// Returns nil when none of the terms are highlightable.
+func highlightQuery(terms []search.Term) map[string]any {
+	fields := make(map[string]any)
+	for _, t := range terms {
+		h, ok := t.(search.Highlightable)
+		if !ok {
+			continue
+		}
+
+		for _, f := range h.HighlightFields() {
+			fields[f] = map[string]any{}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return map[string]any{
+		"encoder":             "html",
+		"pre_tags":            []string{"<em>"},
+		"post_tags":           []string{"</em>"},
+		"fragment_size":       150,
+		"number_of_fragments": 3,
+		"fields":              fields,
+	}
+}
+
+func hasFacet(facets []FacetRequest, field Field) bool {
+	for _, f := range facets {
+		if f.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func facetAggregationName(field Field) string {
+	return "facet_" + string(field)
+}
+
+// facetFilterQuery combines the filters of every faceted field except the excluded one.
+// Returns nil when there is nothing to filter on.
+func facetFilterQuery(facetFilters map[Field][]any, exclude Field) any {
+	var must []any
+	for _, field := range slices.Sorted(maps.Keys(facetFilters)) {
+		if field == exclude {
+			continue
+		}
+
+		must = append(must, facetFilters[field]...)
+	}
+
+	if len(must) == 0 {
+		return nil
+	}
+
+	return map[string]any{
+		"bool": map[string]any{"must": must},
+	}
+}
+
+// facetAggregations builds a filtered terms aggregation per requested facet.
+// Each facet's filter applies all other facet field filters, but not its own.
+func facetAggregations(facets []FacetRequest, facetFilters map[Field][]any) map[string]any {
+	aggs := make(map[string]any, len(facets))
+	for _, f := range facets {
+		filter := facetFilterQuery(facetFilters, f.Field)
+		if filter == nil {
+			filter = map[string]any{"match_all": map[string]any{}}
+		}
+
+		aggs[facetAggregationName(f.Field)] = map[string]any{
+			"filter": filter,
+			"aggs": map[string]any{
+				"values": map[string]any{
+					"terms": map[string]any{
+						"field": f.OSField,
+						"size":  f.Size,
+						"order": map[string]any{"_key": "asc"},
+					},
+				},
+			},
+		}
+	}
+
+	return aggs
+}
+
+// sortQuery builds the OpenSearch sort clause for the requested sorts.
+// Defaults to sorting by start time, and always ends with a gameId tiebreaker
+// so that search_after pagination is stable across events with equal sort values.
+func sortQuery(sorts []SortEntry) []any {
+	if len(sorts) == 0 {
+		sorts = []SortEntry{{Field: StartDateTime, Dir: "asc"}}
+	}
+
+	sortEntries := make([]any, 0, len(sorts)+1)
+	hasTiebreaker := false
+	for _, s := range sorts {
+		fieldName := string(s.Field)
+		if _, isText := textSortFields[s.Field]; isText {
+			fieldName = fieldName + ".keyword"
+		}
+
+		if s.Field == GameID {
+			hasTiebreaker = true
+		}
+
+		if s.Field == Day {
+			sortEntries = append(sortEntries, daySort(s.Dir))
+			continue
+		}
+
+		sortEntries = append(sortEntries, map[string]any{
+			fieldName: map[string]any{"order": s.Dir},
+		})
+	}
+
+	if !hasTiebreaker {
+		sortEntries = append(sortEntries, map[string]any{
+			string(GameID): map[string]any{"order": "asc"},
+		})
+	}
+
+	return sortEntries
+}
+
+// daySort sorts on the day in convention order rather than alphabetically,
+// events without a day sort last.
+func daySort(dir string) map[string]any {
+	order := make(map[string]any, len(conventionDays))
+	for i, d := range conventionDays {
+		order[string(d)] = i
+	}
+
+	return map[string]any{
+		"_script": map[string]any{
+			"type":  "number",
+			"order": dir,
+			"script": map[string]any{
+				"lang": "painless",
+				"source": "if (doc['day'].size() == 0) { return params.missing; } " +
+					"return params.order.getOrDefault(doc['day'].value, params.missing);",
+				"params": map[string]any{
+					"order":   order,
+					"missing": len(conventionDays),
+				},
+			},
+		},
+	}
+}
+
 // KeywordFacet is a single aggregation bucket from OpenSearch.
 type KeywordFacet struct {
 	Value string
@@ -309,7 +504,8 @@ type KeywordFacet struct {
 // GetKeywordFacets returns distinct values and counts for any keyword (or keyword subfield) in the index.
 // field should be a keyword field or a .keyword subfield (e.g. "gameSystem.keyword").
 // size controls the maximum number of buckets returned.
-func (r *EventRepo) GetKeywordFacets(ctx context.Context, field string, size int) ([]KeywordFacet, error) {
+// Only events matching every provided term are counted.
+func (r *EventRepo) GetKeywordFacets(ctx context.Context, field string, size int, terms ...search.Term) ([]KeywordFacet, error) {
 	body := map[string]any{
 		"size": 0,
 		"aggs": map[string]any{
@@ -323,6 +519,15 @@ func (r *EventRepo) GetKeywordFacets(ctx context.Context, field string, size int
 		},
 	}
 
+	query, err := filterQuery(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	if query != nil {
+		body["query"] = query
+	}
+
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal facet request: %w", err)
@@ -351,10 +556,7 @@ func (r *EventRepo) GetKeywordFacets(ctx context.Context, field string, size int
 	var raw struct {
 		Aggregations struct {
 			FacetValues struct {
-				Buckets []struct {
-					Key      string `json:"key"`
-					DocCount int64  `json:"doc_count"`
-				} `json:"buckets"`
+				Buckets []keywordBucket `json:"buckets"`
 			} `json:"facet_values"`
 		} `json:"aggregations"`
 	}
@@ -367,14 +569,428 @@ func (r *EventRepo) GetKeywordFacets(ctx context.Context, field string, size int
 		return nil, fmt.Errorf("failed to unmarshal facet response: %w", err)
 	}
 
-	facets := make([]KeywordFacet, 0, len(raw.Aggregations.FacetValues.Buckets))
-	for _, b := range raw.Aggregations.FacetValues.Buckets {
+	return keywordFacetsFromBuckets(raw.Aggregations.FacetValues.Buckets), nil
+}
+
+// filterQuery combines the terms into a single bool must query.
+// Returns nil when there are no terms.
+func filterQuery(terms []search.Term) (any, error) {
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var (
+		must       = make([]any, 0, len(terms))
+		termErrors []error
+	)
+
+	for _, t := range terms {
+		query, err := t.ToQuery()
+		if err != nil {
+			termErrors = append(termErrors, err)
+			continue
+		}
+
+		must = append(must, query)
+	}
+
+	if len(termErrors) > 0 {
+		return nil, errors.Join(termErrors...)
+	}
+
+	return map[string]any{
+		"bool": map[string]any{"must": must},
+	}, nil
+}
+
+// HistogramFacetRequest asks for the distribution of a numeric or date field.
+type HistogramFacetRequest struct {
+	Field Field
+	// Interval is the numeric bucket width, or a calendar interval (day, hour) for date fields.
+	Interval string
+	// Ranges buckets the field by explicit ranges instead of a fixed interval.
+	// Ranges must include their min and exclude their max, matching OpenSearch range buckets.
+	Ranges []search.Range
+	// Terms filter which events are counted
+	Terms []search.Term
+}
+
+// HistogramBucket is a single histogram, date histogram, or range aggregation bucket.
+type HistogramBucket struct {
+	// Key is the lower bound of the bucket. Date keys are epoch milliseconds.
+	Key float64
+	// KeyAsString is the formatted date of a date bucket, or the name of a range bucket
+	KeyAsString string
+	From        *float64
+	To          *float64
+	Count       int64
+}
+
+// DateHistogramIntervals are the supported calendar intervals for date histograms.
+var DateHistogramIntervals = map[string]struct{}{
+	"day":  {},
+	"hour": {},
+}
+
+// IsDateField is true for fields stored as OpenSearch dates.
+func IsDateField(f Field) bool {
+	switch f {
+	case StartDateTime, EndDateTime, LastModified, AlsoRuns:
+		return true
+	default:
+		return false
+	}
+}
+
+// histogramAggregation builds the aggregation for a histogram facet request.
+func histogramAggregation(req HistogramFacetRequest) (map[string]any, error) {
+	if len(req.Ranges) != 0 {
+		ranges := make([]any, len(req.Ranges))
+		for i, rng := range req.Ranges {
+			if (rng.Min() != "" && !rng.InclusiveMin()) || (rng.Max() != "" && rng.InclusiveMax()) {
+				return nil, fmt.Errorf("facet ranges must include their min and exclude their max, ie [0,4)")
+			}
+
+			bucket := make(map[string]any)
+			if rng.Min() != "" {
+				from, err := strconv.ParseFloat(rng.Min(), 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid facet range min %s: %w", rng.Min(), err)
+				}
+				bucket["from"] = from
+			}
+
+			if rng.Max() != "" {
+				to, err := strconv.ParseFloat(rng.Max(), 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid facet range max %s: %w", rng.Max(), err)
+				}
+				bucket["to"] = to
+			}
+
+			ranges[i] = bucket
+		}
+
+		return map[string]any{
+			"range": map[string]any{
+				"field":  string(req.Field),
+				"ranges": ranges,
+			},
+		}, nil
+	}
+
+	if IsDateField(req.Field) {
+		if _, ok := DateHistogramIntervals[req.Interval]; !ok {
+			return nil, fmt.Errorf("date histogram interval must be day or hour, got %q", req.Interval)
+		}
+
+		return map[string]any{
+			"date_histogram": map[string]any{
+				"field":             string(req.Field),
+				"calendar_interval": req.Interval,
+				"time_zone":         convention.TimeZone,
+				"format":            "yyyy-MM-dd'T'HH:mm:ssXXX",
+				"min_doc_count":     0,
+			},
+		}, nil
+	}
+
+	interval, err := strconv.ParseFloat(req.Interval, 64)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("histogram interval must be a positive number, got %q", req.Interval)
+	}
+
+	return map[string]any{
+		"histogram": map[string]any{
+			"field":         string(req.Field),
+			"interval":      interval,
+			"min_doc_count": 0,
+		},
+	}, nil
+}
+
+// GetHistogramFacets returns the distribution of a numeric or date field as buckets.
+func (r *EventRepo) GetHistogramFacets(ctx context.Context, req HistogramFacetRequest) ([]HistogramBucket, error) {
+	agg, err := histogramAggregation(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"size": 0,
+		"aggs": map[string]any{
+			"facet_histogram": agg,
+		},
+	}
+
+	query, err := filterQuery(req.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	if query != nil {
+		body["query"] = query
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal histogram facet request: %w", err)
+	}
+
+	r.logger.Debug().Msgf("Performing histogram facet request: %s", bodyBytes)
+
+	osReq := opensearchapi.SearchRequest{
+		Index: []string{r.eventIndex},
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	osResp, err := osReq.Do(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := osResp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close histogram facet response body")
+		}
+	}()
+
+	if osResp.IsError() {
+		r.logger.Error().Msgf("histogram facet request failed. Raw response: %s", osResp.String())
+		return nil, fmt.Errorf("failed histogram facet request %d", osResp.StatusCode)
+	}
+
+	var raw struct {
+		Aggregations struct {
+			FacetHistogram struct {
+				Buckets []histogramBucket `json:"buckets"`
+			} `json:"facet_histogram"`
+		} `json:"aggregations"`
+	}
+
+	buff := bytes.NewBuffer([]byte{})
+	if _, err := buff.ReadFrom(osResp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read histogram facet response body: %w", err)
+	}
+	if err := json.Unmarshal(buff.Bytes(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal histogram facet response: %w", err)
+	}
+
+	buckets := make([]HistogramBucket, len(raw.Aggregations.FacetHistogram.Buckets))
+	for i, b := range raw.Aggregations.FacetHistogram.Buckets {
+		buckets[i], err = b.toHistogramBucket()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buckets, nil
+}
+
+// histogramBucket is the raw bucket shape shared by histogram, date_histogram, and range aggregations.
+// Histogram keys are numbers, while range keys are strings.
+type histogramBucket struct {
+	Key         json.RawMessage `json:"key"`
+	KeyAsString string          `json:"key_as_string"`
+	From        *float64        `json:"from"`
+	To          *float64        `json:"to"`
+	DocCount    int64           `json:"doc_count"`
+}
+
+func (b histogramBucket) toHistogramBucket() (HistogramBucket, error) {
+	bucket := HistogramBucket{
+		KeyAsString: b.KeyAsString,
+		From:        b.From,
+		To:          b.To,
+		Count:       b.DocCount,
+	}
+
+	var rangeKey string
+	if err := json.Unmarshal(b.Key, &rangeKey); err == nil {
+		bucket.KeyAsString = rangeKey
+		if b.From != nil {
+			bucket.Key = *b.From
+		}
+		return bucket, nil
+	}
+
+	if err := json.Unmarshal(b.Key, &bucket.Key); err != nil {
+		return HistogramBucket{}, fmt.Errorf("unexpected histogram bucket key %s: %w", b.Key, err)
+	}
+
+	return bucket, nil
+}
+
+type keywordBucket struct {
+	Key      string `json:"key"`
+	DocCount int64  `json:"doc_count"`
+}
+
+func keywordFacetsFromBuckets(buckets []keywordBucket) []KeywordFacet {
+	facets := make([]KeywordFacet, 0, len(buckets))
+	for _, b := range buckets {
 		if b.Key == "" {
 			continue
 		}
 		facets = append(facets, KeywordFacet{Value: b.Key, Count: b.DocCount})
 	}
-	return facets, nil
+	return facets
+}
+
+// Suggest returns ranked completions for the query across the [SuggestFields].
+// Soft-deleted events are not counted.
+func (r *EventRepo) Suggest(ctx context.Context, query string, size int) ([]Suggestion, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("cannot suggest completions for an empty query")
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("size cannot be less than 1, got %d", size)
+	}
+
+	aggs := make(map[string]any, len(SuggestFields))
+	for _, f := range SuggestFields {
+		aggs[string(f)] = map[string]any{
+			"filter": map[string]any{
+				"match": map[string]any{
+					string(f) + ".suggest": map[string]any{
+						"query":    query,
+						"operator": "and",
+					},
+				},
+			},
+			"aggs": map[string]any{
+				"values": map[string]any{
+					"terms": map[string]any{
+						"field": string(f) + ".keyword",
+						"size":  size,
+					},
+				},
+			},
+		}
+	}
+
+	body := map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"term": map[string]any{string(Deleted): false}},
+				},
+			},
+		},
+		"aggs": aggs,
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal suggest request: %w", err)
+	}
+
+	r.logger.Debug().Msgf("Performing suggest request: %s", bodyBytes)
+
+	osReq := opensearchapi.SearchRequest{
+		Index: []string{r.eventIndex},
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	osResp, err := osReq.Do(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := osResp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close suggest response body")
+		}
+	}()
+
+	if osResp.IsError() {
+		r.logger.Error().Msgf("suggest request failed. Raw response: %s", osResp.String())
+		return nil, fmt.Errorf("failed suggest request %d", osResp.StatusCode)
+	}
+
+	var raw struct {
+		Aggregations map[string]struct {
+			Values struct {
+				Buckets []keywordBucket `json:"buckets"`
+			} `json:"values"`
+		} `json:"aggregations"`
+	}
+
+	buff := bytes.NewBuffer([]byte{})
+	if _, err := buff.ReadFrom(osResp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read suggest response body: %w", err)
+	}
+	if err := json.Unmarshal(buff.Bytes(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal suggest response: %w", err)
+	}
+
+	var suggestions []Suggestion
+	for _, f := range SuggestFields {
+		for _, facet := range keywordFacetsFromBuckets(raw.Aggregations[string(f)].Values.Buckets) {
+			suggestions = append(suggestions, Suggestion{
+				Value: facet.Value,
+				Field: f,
+				Count: facet.Count,
+			})
+		}
+	}
+
+	return rankSuggestions(query, suggestions, size), nil
+}
+
+// Similar finds events that are like the request's source event, most similar first.
+func (r *EventRepo) Similar(ctx context.Context, req SimilarRequest) ([]*Event, error) {
+	body, err := similarQuery(r.eventIndex, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal similar request: %w", err)
+	}
+
+	r.logger.Debug().Msgf("Performing similar request: %s", bodyBytes)
+
+	osReq := opensearchapi.SearchRequest{
+		Index: []string{r.eventIndex},
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	osResp, err := osReq.Do(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := osResp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close similar response body")
+		}
+	}()
+
+	if osResp.IsError() {
+		r.logger.Error().Msgf("similar request failed. Raw response: %s", osResp.String())
+		return nil, fmt.Errorf("failed similar request %d", osResp.StatusCode)
+	}
+
+	var (
+		response eventSearchResponse
+		buff     = bytes.NewBuffer([]byte{})
+	)
+
+	if _, err := buff.ReadFrom(osResp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read similar response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal similar response: %w", err)
+	}
+
+	events := make([]*Event, len(response.Hits.Hits))
+	for i, e := range response.Hits.Hits {
+		events[i] = e.Event
+	}
+
+	return events, nil
 }
 
 func (r *EventRepo) FetchEvents(ctx context.Context, ids ...string) (FetchEventsResponse, error) {
@@ -465,13 +1081,19 @@ type eventSearchResponse struct {
 		} `json:"total"`
 		MaxScore float64 `json:"max_score"`
 		Hits     []struct {
-			Index string          `json:"_index"`
-			ID    string          `json:"_id"`
-			Score float64         `json:"_score"`
-			Event *Event          `json:"_source,omitempty"`
-			Sort  json.RawMessage `json:"sort,omitempty"`
+			Index     string              `json:"_index"`
+			ID        string              `json:"_id"`
+			Score     float64             `json:"_score"`
+			Event     *Event              `json:"_source,omitempty"`
+			Sort      json.RawMessage     `json:"sort,omitempty"`
+			Highlight map[string][]string `json:"highlight,omitempty"`
 		} `json:"hits"`
 	} `json:"hits"`
+	Aggregations map[string]struct {
+		Values struct {
+			Buckets []keywordBucket `json:"buckets"`
+		} `json:"values"`
+	} `json:"aggregations,omitempty"`
 	Errors bool `json:"errors"`
 	Items  []struct {
 		IndexError *struct {
@@ -0,0 +1,267 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/internal/search"
+)
+
+func TestSortQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		sorts []SortEntry
+		want  []any
+	}{
+		{
+			name: "default sort has a gameId tiebreaker",
+			want: []any{
+				map[string]any{"startDateTime": map[string]any{"order": "asc"}},
+				map[string]any{"gameId": map[string]any{"order": "asc"}},
+			},
+		},
+		{
+			name:  "text fields sort on the keyword subfield",
+			sorts: []SortEntry{{Field: Title, Dir: "desc"}},
+			want: []any{
+				map[string]any{"title.keyword": map[string]any{"order": "desc"}},
+				map[string]any{"gameId": map[string]any{"order": "asc"}},
+			},
+		},
+		{
+			name:  "explicit gameId sort is not duplicated",
+			sorts: []SortEntry{{Field: Cost, Dir: "asc"}, {Field: GameID, Dir: "desc"}},
+			want: []any{
+				map[string]any{"cost": map[string]any{"order": "asc"}},
+				map[string]any{"gameId": map[string]any{"order": "desc"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, sortQuery(tt.sorts))
+		})
+	}
+}
+
+func TestSortQuery_Day(t *testing.T) {
+	sorts := sortQuery([]SortEntry{{Field: Day, Dir: "desc"}})
+	require.Len(t, sorts, 2)
+
+	script := sorts[0].(map[string]any)["_script"].(map[string]any)
+	require.Equal(t, "number", script["type"])
+	require.Equal(t, "desc", script["order"])
+
+	params := script["script"].(map[string]any)["params"].(map[string]any)
+	order := params["order"].(map[string]any)
+	require.Less(t, order["wed"], order["thu"])
+	require.Less(t, order["sat"], order["sun"])
+	require.Equal(t, len(conventionDays), params["missing"])
+}
+
+func TestFacetAggregations(t *testing.T) {
+	typeFilter := map[string]any{"term": map[string]any{"eventType": "RPG - Roleplaying Game"}}
+	ageFilter := map[string]any{"term": map[string]any{"ageRequired": "Teen (13+)"}}
+
+	facets := []FacetRequest{
+		{Field: EventType, OSField: "eventType", Size: 10},
+		{Field: AgeRequired, OSField: "ageRequired", Size: 5},
+		{Field: GameSystem, OSField: "gameSystem.keyword", Size: 20},
+	}
+	filters := map[Field][]any{
+		EventType:   {typeFilter},
+		AgeRequired: {ageFilter},
+	}
+
+	aggs := facetAggregations(facets, filters)
+	require.Len(t, aggs, 3)
+
+	filterOf := func(name string) any {
+		agg, ok := aggs[name].(map[string]any)
+		require.True(t, ok, "missing aggregation %s", name)
+		return agg["filter"]
+	}
+
+	// each facet excludes its own filter, but applies the others
+	require.Equal(t, map[string]any{"bool": map[string]any{"must": []any{ageFilter}}}, filterOf("facet_eventType"))
+	require.Equal(t, map[string]any{"bool": map[string]any{"must": []any{typeFilter}}}, filterOf("facet_ageRequired"))
+	require.Equal(t, map[string]any{"bool": map[string]any{"must": []any{ageFilter, typeFilter}}}, filterOf("facet_gameSystem"))
+
+	gameSystemAgg := aggs["facet_gameSystem"].(map[string]any)
+	require.Equal(t, map[string]any{
+		"values": map[string]any{
+			"terms": map[string]any{
+				"field": "gameSystem.keyword",
+				"size":  20,
+				"order": map[string]any{"_key": "asc"},
+			},
+		},
+	}, gameSystemAgg["aggs"])
+}
+
+func TestFacetAggregations_NoFilters(t *testing.T) {
+	aggs := facetAggregations([]FacetRequest{{Field: EventType, OSField: "eventType", Size: 10}}, nil)
+
+	agg := aggs["facet_eventType"].(map[string]any)
+	require.Equal(t, map[string]any{"match_all": map[string]any{}}, agg["filter"])
+	require.Nil(t, facetFilterQuery(nil, ""))
+}
+
+func TestHistogramAggregation(t *testing.T) {
+	ranges, err := search.NewGenericValue("[,4),[4,20),[20,)")
+	require.NoError(t, err)
+
+	facetRanges := make([]search.Range, len(ranges))
+	for i, r := range ranges {
+		facetRanges[i] = r.(search.Range)
+	}
+
+	inclusiveMax, err := search.NewRange("[0,4]")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     HistogramFacetRequest
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "numeric histogram",
+			req:  HistogramFacetRequest{Field: Cost, Interval: "2.5"},
+			want: map[string]any{
+				"histogram": map[string]any{"field": "cost", "interval": 2.5, "min_doc_count": 0},
+			},
+		},
+		{
+			name: "date histogram by hour in indy time",
+			req:  HistogramFacetRequest{Field: StartDateTime, Interval: "hour"},
+			want: map[string]any{
+				"date_histogram": map[string]any{
+					"field":             "startDateTime",
+					"calendar_interval": "hour",
+					"time_zone":         "America/Indianapolis",
+					"format":            "yyyy-MM-dd'T'HH:mm:ssXXX",
+					"min_doc_count":     0,
+				},
+			},
+		},
+		{
+			name: "numeric ranges",
+			req:  HistogramFacetRequest{Field: Cost, Ranges: facetRanges},
+			want: map[string]any{
+				"range": map[string]any{
+					"field": "cost",
+					"ranges": []any{
+						map[string]any{"to": 4.0},
+						map[string]any{"from": 4.0, "to": 20.0},
+						map[string]any{"from": 20.0},
+					},
+				},
+			},
+		},
+		{
+			name:    "ranges must exclude their max",
+			req:     HistogramFacetRequest{Field: Cost, Ranges: []search.Range{inclusiveMax}},
+			wantErr: true,
+		},
+		{
+			name:    "numeric interval must be positive",
+			req:     HistogramFacetRequest{Field: Duration, Interval: "0"},
+			wantErr: true,
+		},
+		{
+			name:    "numeric interval must be a number",
+			req:     HistogramFacetRequest{Field: Duration, Interval: "day"},
+			wantErr: true,
+		},
+		{
+			name:    "date interval must be a calendar interval",
+			req:     HistogramFacetRequest{Field: StartDateTime, Interval: "5"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := histogramAggregation(tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHistogramBucketKeys(t *testing.T) {
+	from := 4.0
+	to := 20.0
+
+	tests := []struct {
+		name string
+		raw  histogramBucket
+		want HistogramBucket
+	}{
+		{
+			name: "numeric key",
+			raw:  histogramBucket{Key: []byte(`5.0`), DocCount: 3},
+			want: HistogramBucket{Key: 5, Count: 3},
+		},
+		{
+			name: "date key",
+			raw:  histogramBucket{Key: []byte(`1722470400000`), KeyAsString: "2024-08-01T00:00:00-04:00", DocCount: 7},
+			want: HistogramBucket{Key: 1722470400000, KeyAsString: "2024-08-01T00:00:00-04:00", Count: 7},
+		},
+		{
+			name: "range key",
+			raw:  histogramBucket{Key: []byte(`"4.0-20.0"`), From: &from, To: &to, DocCount: 2},
+			want: HistogramBucket{Key: 4, KeyAsString: "4.0-20.0", From: &from, To: &to, Count: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.raw.toHistogramBucket()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHighlightQuery(t *testing.T) {
+	mustTerm := func(field, value string) search.Term {
+		term, err := NewSearchField(field, value)
+		require.NoError(t, err)
+		return FieldTerm{Term: term, Field: Field(field)}
+	}
+
+	t.Run("no highlightable terms", func(t *testing.T) {
+		require.Nil(t, highlightQuery([]search.Term{mustTerm("cost", "[1,4]"), mustTerm("deleted", "false")}))
+	})
+
+	t.Run("filter and text terms", func(t *testing.T) {
+		got := highlightQuery([]search.Term{
+			mustTerm("filter", "dragons"),
+			mustTerm("gmNames", "smith"),
+			mustTerm("email", "gm@example.com"),
+			mustTerm("eventType", "RPG"),
+		})
+		require.NotNil(t, got)
+		require.Equal(t, "html", got["encoder"])
+
+		fields, ok := got["fields"].(map[string]any)
+		require.True(t, ok)
+
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		require.ElementsMatch(t, []string{
+			"title", "shortDescription", "longDescription", "gameSystem", "group",
+			"gmNames", "email", "email.stop",
+		}, names)
+	})
+}
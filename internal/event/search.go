@@ -1,8 +1,14 @@
 package event
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gencon_buddy_api/internal/search"
 )
@@ -15,12 +21,78 @@ type SearchRequest struct {
 	Limit       int
 	Sorts       []SortEntry
 	SearchAfter []byte
+	Facets      []FacetRequest
+	// Highlight requests fragments of the text fields each event matched on
+	Highlight bool
+	// ExcludeConflictsWith are the game ids of events the results cannot overlap in time
+	ExcludeConflictsWith []string
+	// ConflictBuffer pads the excluded events on both sides, ie walking time between events
+	ConflictBuffer time.Duration
+}
+
+// FilterHash is a hash of everything that filters the search: its terms, in any order,
+// and the events it excludes conflicts with. Paging and sorting do not change the hash.
+func (s SearchRequest) FilterHash() (string, error) {
+	filters := make([]string, 0, len(s.Terms)+1)
+	for _, t := range s.Terms {
+		query, err := t.ToQuery()
+		if err != nil {
+			return "", err
+		}
+
+		b, err := json.Marshal(query)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal search term: %w", err)
+		}
+
+		filters = append(filters, string(b))
+	}
+
+	slices.Sort(filters)
+
+	if len(s.ExcludeConflictsWith) != 0 {
+		ids := slices.Clone(s.ExcludeConflictsWith)
+		slices.Sort(ids)
+		filters = append(filters, fmt.Sprintf("conflicts:%s:%s", strings.Join(slices.Compact(ids), ","), s.ConflictBuffer))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(filters, "\n")))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
 }
 
 type SearchResponse struct {
 	TotalEvents int64
 	Events      []*Event
 	SearchAfter []byte
+	Facets      map[Field][]KeywordFacet
+	// Highlights maps event ids to the matched fragments of each highlighted field
+	Highlights map[string]map[string][]string
+}
+
+// FacetRequest asks for the value counts of a field alongside the search results.
+// The counts respect every search term except those on the facet's own field,
+// so multi-select filters still show the counts of their other values.
+type FacetRequest struct {
+	Field Field
+	// OSField is the keyword field or .keyword subfield to aggregate on
+	OSField string
+	Size    int
+}
+
+// FieldTerm associates a [search.Term] with the field it filters on.
+// Terms that are not wrapped in a FieldTerm apply to every facet.
+type FieldTerm struct {
+	search.Term
+	Field Field
+}
+
+// HighlightFields implements [search.Highlightable] when the wrapped term is highlightable.
+func (f FieldTerm) HighlightFields() []string {
+	if h, ok := f.Term.(search.Highlightable); ok {
+		return h.HighlightFields()
+	}
+
+	return nil
 }
 
 func NewSearchField(f string, value string) (search.Term, error) {
@@ -30,6 +102,11 @@ func NewSearchField(f string, value string) (search.Term, error) {
 		return nil, err
 	}
 
+	// parsed untrimmed, so query errors point at the right column
+	if field == Query {
+		return ParseQuery(value)
+	}
+
 	value = strings.TrimSpace(value)
 
 	switch field {
@@ -69,15 +146,22 @@ func NewSearchField(f string, value string) (search.Term, error) {
 			converted[i] = string(CategoryFromSearchTerm(p))
 		}
 		return search.NewKeywordSlice(f, converted)
+	case Day:
+		parts := strings.Split(value, ",")
+		converted := make([]string, len(parts))
+		for i, p := range parts {
+			converted[i] = string(WeekdayFromSearchTerm(p))
+		}
+		return search.NewKeywordSlice(f, converted)
 	// Keywords that have no special consideration
-	case GameID, MaterialsRequired, LastChangeLogModification:
+	case GameID, BggID, MaterialsRequired, LastChangeLogModification:
 		return search.NewKeyword(f, value)
 	// GameSystem is a text field with a .keyword subfield; exact match requires the subfield
 	case GameSystem:
 		return search.NewKeyword(f+".keyword", value)
 	// integer
 	case Year, MinPlayers, MaxPlayers, RoundNumber,
-		TotalRounds, TicketsAvailable, TotalTickets:
+		TotalRounds, TicketsAvailable, TotalTickets, StartHour, EndHour, BggRank:
 		return search.NewNumber(f, value)
 	// Generic full text search fields
 	case Group, Title, ShortDescription, LongDescription,
@@ -99,7 +183,7 @@ func NewSearchField(f string, value string) (search.Term, error) {
 
 		return search.NewBool().Should(text, stopText), err
 	// double
-	case Duration, MinimumPlayTime, Cost:
+	case Duration, MinimumPlayTime, Cost, TicketsSoldPerHour, BggAvgRating:
 		return search.NewNumber(f, value)
 	// Date searches
 	case StartDateTime, EndDateTime, LastModified, AlsoRuns:
@@ -114,14 +198,89 @@ func NewSearchField(f string, value string) (search.Term, error) {
 	// Special filter
 	case Filter:
 		return FilterTerm{value: value}, nil
+	case FitsIn:
+		return NewFitsInTerm(value)
+	case PartySize:
+		return NewPartySizeTerm(value)
+	case HasBgg:
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("cannot search against a boolean field with value [%s], only 'true' or 'false' allowed", value)
+		}
+
+		return HasBggTerm{hasBgg: value == "true"}, nil
 	default:
 		return nil, fmt.Errorf("Field %s is not supported as a search field", f)
 	}
 }
 
+// Operator compares a field to a value in [NewComparisonField]
+type Operator string
+
+const (
+	Equals             Operator = "eq"
+	LessThan           Operator = "lt"
+	LessThanOrEqual    Operator = "lte"
+	GreaterThan        Operator = "gt"
+	GreaterThanOrEqual Operator = "gte"
+	Phrase             Operator = "phrase"
+)
+
+// NewComparisonField creates the search term comparing a field to a value.
+// Equals takes the same values as the field's query param in [NewSearchField].
+// The ordering operators take a single value, and only work on number and date fields.
+// Phrase matches text fields on the value's words in order, and is Equals on other fields.
+// Unlike [NewSearchField], invalid values are an error when the term is created.
+func NewComparisonField(f string, op Operator, value string) (search.Term, error) {
+	switch op {
+	case Equals, Phrase:
+	case LessThan, LessThanOrEqual, GreaterThan, GreaterThanOrEqual:
+		if strings.ContainsAny(value, ",[]()") {
+			return nil, fmt.Errorf("%s can only compare against a single value", op)
+		}
+
+		switch op {
+		case LessThan:
+			value = "(," + value + ")"
+		case LessThanOrEqual:
+			value = "(," + value + "]"
+		case GreaterThan:
+			value = "(" + value + ",)"
+		case GreaterThanOrEqual:
+			value = "[" + value + ",)"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", op)
+	}
+
+	term, err := NewSearchField(f, value)
+	if err != nil {
+		return nil, err
+	}
+
+	_, isNumber := term.(search.Number)
+	_, isDate := term.(search.Date)
+	if op != Equals && op != Phrase && !isNumber && !isDate {
+		return nil, fmt.Errorf("%s is not a number or date field, so it does not support %s", f, op)
+	}
+
+	if _, ok := term.(search.Text); ok && op == Phrase {
+		term, err = search.NewTextPhrase(f, strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// surfaces the value errors the terms only check when building their query
+	if _, err := term.ToQuery(); err != nil {
+		return nil, err
+	}
+
+	return term, nil
+}
+
 // ParseSort parses a "{field}.{asc|desc}" sort string.
 // Returns the validated Field, direction, and any parse/validation error.
-// The virtual "filter" field is not sortable.
+// Virtual fields are not sortable.
 func ParseSort(s string) (Field, string, error) {
 	parts := strings.SplitN(s, ".", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
@@ -139,8 +298,8 @@ func ParseSort(s string) (Field, string, error) {
 		return "", "", fmt.Errorf("invalid sort field: %w", err)
 	}
 
-	if field == Filter {
-		return "", "", fmt.Errorf("filter is a virtual field and cannot be used for sorting")
+	if field.IsVirtual() {
+		return "", "", fmt.Errorf("%s is a virtual field and cannot be used for sorting", field)
 	}
 
 	return field, dir, nil
@@ -184,6 +343,25 @@ type FilterTerm struct {
 	value string
 }
 
+// filterTermFields are the fields the [FilterTerm] matches against
+var filterTermFields = []Field{
+	Title,
+	ShortDescription,
+	LongDescription,
+	GameSystem,
+	Group,
+}
+
+// HighlightFields implements [search.Highlightable]
+func (f FilterTerm) HighlightFields() []string {
+	fields := make([]string, len(filterTermFields))
+	for i, field := range filterTermFields {
+		fields[i] = string(field)
+	}
+
+	return fields
+}
+
 func (f FilterTerm) ToQuery() (any, error) {
 	inFixValue := fmt.Sprintf("*%s*", f.value)
 	return map[string]any{
@@ -224,6 +402,217 @@ func (f FilterTerm) ToQuery() (any, error) {
 	}, nil
 }
 
+// PartySizeTerm is a special [search.Term] implementation for a virtual "partySize" field.
+// It matches events the whole party can play in together:
+//   - Min Players <= size <= Max Players
+//   - Tickets Available >= size, unless the event is generic ticket-only
+//   - Age Required is kids only or everyone, when the party includes kids
+type PartySizeTerm struct {
+	size     int64
+	withKids bool
+}
+
+// partySizeKidsOption marks a party that includes kids, e.g. partySize=4,kids
+const partySizeKidsOption = "kids"
+
+// NewPartySizeTerm parses the party size, optionally followed by ",kids"
+func NewPartySizeTerm(value string) (PartySizeTerm, error) {
+	sizeStr, option, hasOption := strings.Cut(value, ",")
+
+	size, err := strconv.ParseInt(strings.TrimSpace(sizeStr), 10, 64)
+	if err != nil {
+		return PartySizeTerm{}, fmt.Errorf("%s must be a whole number, got [%s]", PartySize, sizeStr)
+	}
+
+	if size < 1 {
+		return PartySizeTerm{}, fmt.Errorf("%s must be at least 1, got %d", PartySize, size)
+	}
+
+	if hasOption && strings.TrimSpace(option) != partySizeKidsOption {
+		return PartySizeTerm{}, fmt.Errorf("unsupported %s option [%s], only '%s' is allowed", PartySize, option, partySizeKidsOption)
+	}
+
+	return PartySizeTerm{size: size, withKids: hasOption}, nil
+}
+
+func (p PartySizeTerm) ToQuery() (any, error) {
+	size := strconv.FormatInt(p.size, 10)
+
+	minPlayers, err := search.NewNumber(string(MinPlayers), "(,"+size+"]")
+	if err != nil {
+		return nil, err
+	}
+
+	maxPlayers, err := search.NewNumber(string(MaxPlayers), "["+size+",)")
+	if err != nil {
+		return nil, err
+	}
+
+	ticketsAvailable, err := search.NewNumber(string(TicketsAvailable), "["+size+",)")
+	if err != nil {
+		return nil, err
+	}
+
+	// generic ticket-only events take generic tickets, so their ticket counts do not apply
+	genericTickets, err := search.NewKeywordSingle(string(AttendeeRegistration), string(Generic))
+	if err != nil {
+		return nil, err
+	}
+
+	query := search.NewBool().Must(
+		minPlayers,
+		maxPlayers,
+		search.NewBool().Should(ticketsAvailable, genericTickets),
+	)
+
+	if p.withKids {
+		ageRequired, err := search.NewKeywordSlice(string(AgeRequired), []string{string(Kids), string(Everyone)})
+		if err != nil {
+			return nil, err
+		}
+
+		query.Must(ageRequired)
+	}
+
+	return query.ToQuery()
+}
+
+// HasBggTerm is a special [search.Term] implementation for a virtual "hasBgg" field.
+// It matches events that were, or were not, matched to a BGG game by [HydrateBGG].
+type HasBggTerm struct {
+	hasBgg bool
+}
+
+func (h HasBggTerm) ToQuery() (any, error) {
+	// events without a BGG game store an empty bggId
+	query := map[string]any{
+		"bool": map[string]any{
+			"must":     []any{map[string]any{"exists": map[string]any{"field": string(BggID)}}},
+			"must_not": []any{map[string]any{"term": map[string]any{string(BggID): ""}}},
+		},
+	}
+
+	if h.hasBgg {
+		return query, nil
+	}
+
+	return map[string]any{
+		"bool": map[string]any{"must_not": []any{query}},
+	}, nil
+}
+
+// FitsInTerm is a special [search.Term] implementation for a virtual "fitsIn" field.
+// It matches events that start and end inside any of its time windows,
+// ie startDateTime >= from and endDateTime <= to.
+type FitsInTerm struct {
+	windows []search.Range
+}
+
+// NewFitsInTerm parses comma separated date ranges, each with both a start and an end,
+// e.g. [2025-07-31T10:00:00-04:00,2025-07-31T14:00:00-04:00],[2025-08-01T18:00:00-04:00,2025-08-01T23:00:00-04:00].
+// Exclusive brackets exclude events starting or ending exactly on the bound.
+func NewFitsInTerm(value string) (FitsInTerm, error) {
+	values, err := search.NewGenericValue(value)
+	if err != nil {
+		return FitsInTerm{}, fmt.Errorf("invalid %s windows: %w", FitsIn, err)
+	}
+
+	windows := make([]search.Range, len(values))
+	for i, v := range values {
+		window, ok := v.(search.Range)
+		if !ok {
+			return FitsInTerm{}, fmt.Errorf("%s window %v must be a date range, e.g. [from,to]", FitsIn, v)
+		}
+
+		if window.Min() == "" || window.Max() == "" {
+			return FitsInTerm{}, fmt.Errorf("%s window %d needs both a start and an end", FitsIn, i)
+		}
+
+		from, err := time.Parse(time.RFC3339, window.Min())
+		if err != nil {
+			return FitsInTerm{}, fmt.Errorf("%s window %d has an invalid start: %w", FitsIn, i, err)
+		}
+
+		to, err := time.Parse(time.RFC3339, window.Max())
+		if err != nil {
+			return FitsInTerm{}, fmt.Errorf("%s window %d has an invalid end: %w", FitsIn, i, err)
+		}
+
+		if !from.Before(to) {
+			return FitsInTerm{}, fmt.Errorf("%s window %d must start before it ends", FitsIn, i)
+		}
+
+		windows[i] = window
+	}
+
+	return FitsInTerm{windows: windows}, nil
+}
+
+func (f FitsInTerm) ToQuery() (any, error) {
+	windows := make([]search.Term, len(f.windows))
+	for i, w := range f.windows {
+		startBracket, endBracket := "(", ")"
+		if w.InclusiveMin() {
+			startBracket = "["
+		}
+		if w.InclusiveMax() {
+			endBracket = "]"
+		}
+
+		start, err := search.NewDate(string(StartDateTime), startBracket+w.Min()+",)")
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := search.NewDate(string(EndDateTime), "(,"+w.Max()+endBracket)
+		if err != nil {
+			return nil, err
+		}
+
+		windows[i] = search.NewBool().Must(start, end)
+	}
+
+	if len(windows) == 1 {
+		return windows[0].ToQuery()
+	}
+
+	return search.NewBool().Should(windows...).ToQuery()
+}
+
+// NewConflictsTerm matches events that overlap none of the given events, with each event's
+// time widened by the buffer on both sides. Events that touch end to start do not overlap.
+// Returns nil when there are no events to conflict with.
+func NewConflictsTerm(events []*Event, buffer time.Duration) (search.Term, error) {
+	overlaps := make([]search.Term, 0, len(events))
+	for _, e := range events {
+		if e.StartDateTime.IsZero() || e.EndDateTime.IsZero() {
+			continue
+		}
+
+		start := e.StartDateTime.Add(-buffer).Format(time.RFC3339)
+		end := e.EndDateTime.Add(buffer).Format(time.RFC3339)
+
+		// overlapping events start before this one ends, and end after it starts
+		startsBefore, err := search.NewDate(string(StartDateTime), "(,"+end+")")
+		if err != nil {
+			return nil, err
+		}
+
+		endsAfter, err := search.NewDate(string(EndDateTime), "("+start+",)")
+		if err != nil {
+			return nil, err
+		}
+
+		overlaps = append(overlaps, search.NewBool().Must(startsBefore, endsAfter))
+	}
+
+	if len(overlaps) == 0 {
+		return nil, nil
+	}
+
+	return search.NewBool().MustNot(overlaps...), nil
+}
+
 type Field string
 
 func FieldFromString(s string) (Field, error) {
@@ -235,10 +624,24 @@ func FieldFromString(s string) (Field, error) {
 	return Field(s), nil
 }
 
+// IsVirtual reports whether the field is only a search param, not a field of the event index.
+// Virtual fields cannot be sorted or faceted on.
+func (f Field) IsVirtual() bool {
+	_, ok := virtualFields[f]
+	return ok
+}
+
 // All the valid search fields for events
 const (
 	Filter                    Field = "filter"
+	Query                     Field = "q"
+	FitsIn                    Field = "fitsIn"
+	PartySize                 Field = "partySize"
+	HasBgg                    Field = "hasBgg"
 	GameID                    Field = "gameId"
+	BggID                     Field = "bggId"
+	BggRank                   Field = "bggRank"
+	BggAvgRating              Field = "bggAvgRating"
 	Year                      Field = "year"
 	Group                     Field = "group"
 	Title                     Field = "title"
@@ -257,6 +660,9 @@ const (
 	StartDateTime             Field = "startDateTime"
 	Duration                  Field = "duration"
 	EndDateTime               Field = "endDateTime"
+	Day                       Field = "day"
+	StartHour                 Field = "startHour"
+	EndHour                   Field = "endHour"
 	GMNames                   Field = "gmNames"
 	Website                   Field = "website"
 	Email                     Field = "email"
@@ -272,6 +678,7 @@ const (
 	SpecialCategory           Field = "specialCategory"
 	TicketsAvailable          Field = "ticketsAvailable"
 	TotalTickets              Field = "totalTickets"
+	TicketsSoldPerHour        Field = "ticketsSoldPerHour"
 	LastModified              Field = "lastModified"
 	AlsoRuns                  Field = "alsoRuns"
 	Prize                     Field = "prize"
@@ -284,7 +691,14 @@ const (
 var (
 	allFields = map[Field]any{
 		Filter:                    struct{}{},
+		Query:                     struct{}{},
+		FitsIn:                    struct{}{},
+		PartySize:                 struct{}{},
+		HasBgg:                    struct{}{},
 		GameID:                    struct{}{},
+		BggID:                     struct{}{},
+		BggRank:                   struct{}{},
+		BggAvgRating:              struct{}{},
 		Year:                      struct{}{},
 		Group:                     struct{}{},
 		Title:                     struct{}{},
@@ -303,6 +717,9 @@ var (
 		StartDateTime:             struct{}{},
 		Duration:                  struct{}{},
 		EndDateTime:               struct{}{},
+		Day:                       struct{}{},
+		StartHour:                 struct{}{},
+		EndHour:                   struct{}{},
 		GMNames:                   struct{}{},
 		Website:                   struct{}{},
 		Email:                     struct{}{},
@@ -318,6 +735,7 @@ var (
 		SpecialCategory:           struct{}{},
 		TicketsAvailable:          struct{}{},
 		TotalTickets:              struct{}{},
+		TicketsSoldPerHour:        struct{}{},
 		LastModified:              struct{}{},
 		AlsoRuns:                  struct{}{},
 		Prize:                     struct{}{},
@@ -326,6 +744,15 @@ var (
 		LastChangeLogModification: struct{}{},
 		Deleted:                   struct{}{},
 	}
+
+	// virtualFields are the search params that build their own queries rather than search a field
+	virtualFields = map[Field]struct{}{
+		Filter:    {},
+		Query:     {},
+		FitsIn:    {},
+		PartySize: {},
+		HasBgg:    {},
+	}
 )
 
 type OpType uint
@@ -2,6 +2,7 @@ package event
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -39,6 +40,27 @@ func TestParseSort(t *testing.T) {
 			input:   "filter.asc",
 			wantErr: true,
 		},
+		{
+			name:    "q field is rejected",
+			input:   "q.asc",
+			wantErr: true,
+		},
+		{
+			name:      "bgg field desc",
+			input:     "bggAvgRating.desc",
+			wantField: BggAvgRating,
+			wantDir:   "desc",
+		},
+		{
+			name:    "hasBgg field is rejected",
+			input:   "hasBgg.asc",
+			wantErr: true,
+		},
+		{
+			name:    "fitsIn field is rejected",
+			input:   "fitsIn.asc",
+			wantErr: true,
+		},
 		{
 			name:    "unknown field is rejected",
 			input:   "bogus.asc",
@@ -227,6 +249,14 @@ func TestNewSearchField_MultiValue(t *testing.T) {
 				"terms": map[string]any{"specialCategory": []string{"Gen Con presents", "Premier Event"}},
 			},
 		},
+		{
+			name:  "day multi value",
+			field: "day",
+			value: "thu,Friday",
+			wantQuery: map[string]any{
+				"terms": map[string]any{"day": []string{"thu", "fri"}},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -245,3 +275,328 @@ func TestNewSearchField_MultiValue(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFitsInTerm(t *testing.T) {
+	window := func(start, end string) map[string]any {
+		return map[string]any{
+			"bool": map[string]any{
+				"must": []any{
+					map[string]any{"range": map[string]any{"startDateTime": map[string]any{"gte": start}}},
+					map[string]any{"range": map[string]any{"endDateTime": map[string]any{"lte": end}}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		wantQuery any
+	}{
+		{
+			name:      "single window",
+			value:     "[2025-07-31T10:00:00-04:00,2025-07-31T14:00:00-04:00]",
+			wantQuery: window("2025-07-31T10:00:00-04:00", "2025-07-31T14:00:00-04:00"),
+		},
+		{
+			name:  "multiple windows match any",
+			value: "[2025-07-31T10:00:00-04:00,2025-07-31T14:00:00-04:00],[2025-08-01T22:00:00Z,2025-08-02T03:00:00Z]",
+			wantQuery: map[string]any{
+				"bool": map[string]any{
+					"should": []any{
+						window("2025-07-31T10:00:00-04:00", "2025-07-31T14:00:00-04:00"),
+						window("2025-08-01T18:00:00-04:00", "2025-08-01T23:00:00-04:00"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := NewSearchField("fitsIn", tt.value)
+			require.NoError(t, err)
+
+			query, err := term.ToQuery()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+		})
+	}
+}
+
+func TestNewFitsInTerm_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{
+			name:    "not a range",
+			value:   "2025-07-31T10:00:00-04:00",
+			wantErr: "must be a date range",
+		},
+		{
+			name:    "open ended window",
+			value:   "[2025-07-31T10:00:00-04:00,)",
+			wantErr: "needs both a start and an end",
+		},
+		{
+			name:    "invalid date",
+			value:   "[tomorrow,2025-07-31T14:00:00-04:00]",
+			wantErr: "invalid start",
+		},
+		{
+			name:    "window ends before it starts",
+			value:   "[2025-07-31T14:00:00-04:00,2025-07-31T10:00:00-04:00]",
+			wantErr: "must start before it ends",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSearchField("fitsIn", tt.value)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewConflictsTerm(t *testing.T) {
+	indy, err := time.LoadLocation("America/Indianapolis")
+	require.NoError(t, err)
+
+	events := []*Event{
+		{
+			StartDateTime: time.Date(2025, 7, 31, 10, 0, 0, 0, indy),
+			EndDateTime:   time.Date(2025, 7, 31, 12, 0, 0, 0, indy),
+		},
+		{
+			StartDateTime: time.Date(2025, 8, 1, 18, 0, 0, 0, indy),
+			EndDateTime:   time.Date(2025, 8, 1, 22, 0, 0, 0, indy),
+		},
+	}
+
+	overlap := func(start, end string) map[string]any {
+		return map[string]any{
+			"bool": map[string]any{
+				"must": []any{
+					map[string]any{"range": map[string]any{"startDateTime": map[string]any{"lt": end}}},
+					map[string]any{"range": map[string]any{"endDateTime": map[string]any{"gt": start}}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		buffer    time.Duration
+		wantQuery any
+	}{
+		{
+			name: "no buffer",
+			wantQuery: map[string]any{
+				"bool": map[string]any{
+					"must_not": []any{
+						overlap("2025-07-31T10:00:00-04:00", "2025-07-31T12:00:00-04:00"),
+						overlap("2025-08-01T18:00:00-04:00", "2025-08-01T22:00:00-04:00"),
+					},
+				},
+			},
+		},
+		{
+			name:   "buffer widens both sides",
+			buffer: 15 * time.Minute,
+			wantQuery: map[string]any{
+				"bool": map[string]any{
+					"must_not": []any{
+						overlap("2025-07-31T09:45:00-04:00", "2025-07-31T12:15:00-04:00"),
+						overlap("2025-08-01T17:45:00-04:00", "2025-08-01T22:15:00-04:00"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := NewConflictsTerm(events, tt.buffer)
+			require.NoError(t, err)
+
+			query, err := term.ToQuery()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+		})
+	}
+}
+
+func TestNewConflictsTerm_NoEvents(t *testing.T) {
+	term, err := NewConflictsTerm([]*Event{{}}, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, term)
+}
+
+func TestNewPartySizeTerm(t *testing.T) {
+	term := func(field, value string) search.Term {
+		t.Helper()
+		st, err := NewSearchField(field, value)
+		require.NoError(t, err)
+		return st
+	}
+
+	generic, err := search.NewKeywordSingle("attendeeRegistration", string(Generic))
+	require.NoError(t, err)
+
+	fits := func() *search.Bool {
+		return search.NewBool().Must(
+			term("minPlayers", "(,4]"),
+			term("maxPlayers", "[4,)"),
+			search.NewBool().Should(term("ticketsAvailable", "[4,)"), generic),
+		)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  search.Term
+	}{
+		{
+			name:  "party size",
+			value: "4",
+			want:  fits(),
+		},
+		{
+			name:  "party with kids",
+			value: "4,kids",
+			want:  fits().Must(term("ageRequired", "kids,everyone")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSearchField("partySize", tt.value)
+			require.NoError(t, err)
+
+			wantQuery, err := tt.want.ToQuery()
+			require.NoError(t, err)
+
+			gotQuery, err := got.ToQuery()
+			require.NoError(t, err)
+			require.Equal(t, wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestNewPartySizeTerm_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "not a number", value: "four", wantErr: "must be a whole number"},
+		{name: "empty party", value: "0", wantErr: "must be at least 1"},
+		{name: "unknown option", value: "4,teens", wantErr: "unsupported partySize option"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSearchField("partySize", tt.value)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewSearchField_Bgg(t *testing.T) {
+	ranked := map[string]any{
+		"bool": map[string]any{
+			"must":     []any{map[string]any{"exists": map[string]any{"field": "bggId"}}},
+			"must_not": []any{map[string]any{"term": map[string]any{"bggId": ""}}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		field     string
+		value     string
+		wantQuery any
+		wantErr   bool
+	}{
+		{
+			name:      "bggId",
+			field:     "bggId",
+			value:     "266192",
+			wantQuery: map[string]any{"term": map[string]any{"bggId": "266192"}},
+		},
+		{
+			name:      "hasBgg true",
+			field:     "hasBgg",
+			value:     "true",
+			wantQuery: ranked,
+		},
+		{
+			name:      "hasBgg false",
+			field:     "hasBgg",
+			value:     "false",
+			wantQuery: map[string]any{"bool": map[string]any{"must_not": []any{ranked}}},
+		},
+		{
+			name:    "hasBgg is a boolean",
+			field:   "hasBgg",
+			value:   "yes",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := NewSearchField(tt.field, tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			query, err := term.ToQuery()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+		})
+	}
+}
+
+func TestSearchRequest_FilterHash(t *testing.T) {
+	term := func(field, value string) search.Term {
+		t.Helper()
+		st, err := NewSearchField(field, value)
+		require.NoError(t, err)
+		return FieldTerm{Term: st, Field: Field(field)}
+	}
+
+	hash := func(req SearchRequest) string {
+		t.Helper()
+		h, err := req.FilterHash()
+		require.NoError(t, err)
+		return h
+	}
+
+	base := SearchRequest{Terms: []search.Term{term("eventType", "RPG"), term("cost", "[0,4]")}}
+
+	reordered := SearchRequest{
+		Terms: []search.Term{term("cost", "[0,4]"), term("eventType", "RPG")},
+		Page:  3,
+		Limit: 20,
+		Sorts: []SortEntry{{Field: Title, Dir: "asc"}},
+	}
+	require.Equal(t, hash(base), hash(reordered), "term order, paging, and sorting do not change the filters")
+
+	otherValue := SearchRequest{Terms: []search.Term{term("eventType", "BGM"), term("cost", "[0,4]")}}
+	require.NotEqual(t, hash(base), hash(otherValue))
+
+	fewerTerms := SearchRequest{Terms: []search.Term{term("eventType", "RPG")}}
+	require.NotEqual(t, hash(base), hash(fewerTerms))
+
+	conflicts := base
+	conflicts.ExcludeConflictsWith = []string{"RPG25ND123456"}
+	require.NotEqual(t, hash(base), hash(conflicts))
+
+	buffered := conflicts
+	buffered.ConflictBuffer = 15 * time.Minute
+	require.NotEqual(t, hash(conflicts), hash(buffered))
+}
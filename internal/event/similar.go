@@ -0,0 +1,121 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// SimilarFields are the text fields compared to find events similar to another event
+var SimilarFields = []Field{
+	Title,
+	ShortDescription,
+	LongDescription,
+	GameSystem,
+	Group,
+}
+
+// SimilarRequest asks for events that are like the Source event.
+type SimilarRequest struct {
+	Source *Event
+	Limit  int
+	// IncludeSoldOut keeps events without tickets available in the results
+	IncludeSoldOut bool
+	// WindowStart and WindowEnd limit the results to events overlapping the window.
+	// A zero time leaves that side of the window open.
+	WindowStart time.Time
+	WindowEnd   time.Time
+}
+
+// similarQuery builds the more like this query for the request against the event index.
+// Events sharing the source's EventType or BGG id are boosted, while the source event
+// and soft-deleted events are always excluded.
+func similarQuery(eventIndex string, req SimilarRequest) (map[string]any, error) {
+	if req.Source == nil || req.Source.GameID == "" {
+		return nil, fmt.Errorf("cannot find similar events without a source event")
+	}
+
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit cannot be less than 1, got %d", req.Limit)
+	}
+
+	if !req.WindowStart.IsZero() && !req.WindowEnd.IsZero() && !req.WindowEnd.After(req.WindowStart) {
+		return nil, fmt.Errorf("the time window must end after it starts")
+	}
+
+	fields := make([]string, len(SimilarFields))
+	for i, f := range SimilarFields {
+		fields[i] = string(f)
+	}
+
+	moreLikeThis := map[string]any{
+		"more_like_this": map[string]any{
+			"fields": fields,
+			"like": []any{
+				map[string]any{"_index": eventIndex, "_id": req.Source.GameID},
+			},
+			"min_term_freq":   1,
+			"min_doc_freq":    2,
+			"max_query_terms": 25,
+		},
+	}
+
+	boosts := []any{}
+	if req.Source.EventType != "" {
+		boosts = append(boosts, map[string]any{
+			"term": map[string]any{
+				string(EventType): map[string]any{"value": req.Source.EventType, "boost": 2},
+			},
+		})
+	}
+
+	if req.Source.BggID != "" {
+		boosts = append(boosts, map[string]any{
+			"term": map[string]any{
+				string(BggID): map[string]any{"value": req.Source.BggID, "boost": 3},
+			},
+		})
+	}
+
+	filter := []any{
+		map[string]any{"term": map[string]any{string(Deleted): false}},
+	}
+
+	if !req.IncludeSoldOut {
+		// generic and free events do not reserve event tickets, so they are never sold out
+		filter = append(filter, map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"range": map[string]any{string(TicketsAvailable): map[string]any{"gt": 0}}},
+					map[string]any{"terms": map[string]any{string(AttendeeRegistration): []string{string(Generic), string(Free)}}},
+				},
+				"minimum_should_match": 1,
+			},
+		})
+	}
+
+	if !req.WindowEnd.IsZero() {
+		filter = append(filter, map[string]any{
+			"range": map[string]any{string(StartDateTime): map[string]any{"lt": req.WindowEnd.Format(time.RFC3339)}},
+		})
+	}
+
+	if !req.WindowStart.IsZero() {
+		filter = append(filter, map[string]any{
+			"range": map[string]any{string(EndDateTime): map[string]any{"gt": req.WindowStart.Format(time.RFC3339)}},
+		})
+	}
+
+	return map[string]any{
+		"size": req.Limit,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must":   moreLikeThis,
+				"should": boosts,
+				"filter": filter,
+				"must_not": []any{
+					map[string]any{"ids": map[string]any{"values": []string{req.Source.GameID}}},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,68 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarQuery(t *testing.T) {
+	source := &Event{
+		GameID:    "RPG25ND123456",
+		EventType: "RPG",
+		BggID:     "174430",
+	}
+
+	t.Run("requires a source event", func(t *testing.T) {
+		_, err := similarQuery("events", SimilarRequest{Limit: 10})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects an inverted window", func(t *testing.T) {
+		start := time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+		_, err := similarQuery("events", SimilarRequest{
+			Source:      source,
+			Limit:       10,
+			WindowStart: start,
+			WindowEnd:   start.Add(-time.Hour),
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("boosts, exclusions and sold out filter", func(t *testing.T) {
+		got, err := similarQuery("events", SimilarRequest{Source: source, Limit: 5})
+		require.NoError(t, err)
+		require.Equal(t, 5, got["size"])
+
+		query := got["query"].(map[string]any)["bool"].(map[string]any)
+		require.Equal(t, []any{
+			map[string]any{"term": map[string]any{"eventType": map[string]any{"value": Type("RPG"), "boost": 2}}},
+			map[string]any{"term": map[string]any{"bggId": map[string]any{"value": "174430", "boost": 3}}},
+		}, query["should"])
+		require.Equal(t, []any{
+			map[string]any{"ids": map[string]any{"values": []string{"RPG25ND123456"}}},
+		}, query["must_not"])
+		require.Len(t, query["filter"], 2)
+	})
+
+	t.Run("window and sold out events included", func(t *testing.T) {
+		start := time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+		got, err := similarQuery("events", SimilarRequest{
+			Source:         &Event{GameID: "RPG25ND123456"},
+			Limit:          5,
+			IncludeSoldOut: true,
+			WindowStart:    start,
+			WindowEnd:      start.Add(2 * time.Hour),
+		})
+		require.NoError(t, err)
+
+		query := got["query"].(map[string]any)["bool"].(map[string]any)
+		require.Empty(t, query["should"])
+		require.Equal(t, []any{
+			map[string]any{"term": map[string]any{"deleted": false}},
+			map[string]any{"range": map[string]any{"startDateTime": map[string]any{"lt": "2025-07-31T14:00:00Z"}}},
+			map[string]any{"range": map[string]any{"endDateTime": map[string]any{"gt": "2025-07-31T12:00:00Z"}}},
+		}, query["filter"])
+	})
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"sort"
+	"strings"
+)
+
+// SuggestFields are the fields completions are suggested from. Each field has
+// a .suggest edge n-gram subfield for matching and a .keyword subfield for counting.
+var SuggestFields = []Field{
+	Title,
+	GameSystem,
+	Group,
+	GMNames,
+}
+
+// Suggestion is a single search-as-you-type completion.
+type Suggestion struct {
+	Value string
+	// Field the value was found in
+	Field Field
+	// Count of visible events with this value
+	Count int64
+}
+
+// rankSuggestions orders suggestions so values that start with the query come first,
+// then by the number of events, and finally alphabetically. Only the first size are kept.
+func rankSuggestions(query string, suggestions []Suggestion, size int) []Suggestion {
+	query = strings.ToLower(strings.TrimSpace(query))
+	startsWith := func(s Suggestion) bool {
+		return strings.HasPrefix(strings.ToLower(s.Value), query)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if startsWith(a) != startsWith(b) {
+			return startsWith(a)
+		}
+
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+
+		return a.Value < b.Value
+	})
+
+	if len(suggestions) > size {
+		suggestions = suggestions[:size]
+	}
+
+	return suggestions
+}
@@ -0,0 +1,33 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankSuggestions(t *testing.T) {
+	suggestions := []Suggestion{
+		{Value: "Intro to Dragons", Field: Title, Count: 40},
+		{Value: "Dragon Age", Field: GameSystem, Count: 3},
+		{Value: "dragonfly games", Field: Group, Count: 3},
+		{Value: "Dungeons & Dragons", Field: GameSystem, Count: 900},
+		{Value: "Dragonlance", Field: Title, Count: 12},
+	}
+
+	got := rankSuggestions("Drag", suggestions, 4)
+
+	require.Equal(t, []Suggestion{
+		{Value: "Dragonlance", Field: Title, Count: 12},
+		{Value: "Dragon Age", Field: GameSystem, Count: 3},
+		{Value: "dragonfly games", Field: Group, Count: 3},
+		{Value: "Dungeons & Dragons", Field: GameSystem, Count: 900},
+	}, got)
+}
+
+func TestRankSuggestions_FewerThanSize(t *testing.T) {
+	got := rankSuggestions("cat", []Suggestion{{Value: "Catan", Field: GameSystem, Count: 1}}, 10)
+	require.Len(t, got, 1)
+
+	require.Empty(t, rankSuggestions("cat", nil, 10))
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gencon_buddy_api/gcbapi"
+	"github.com/gencon_buddy_api/internal/convention"
 )
 
 const (
@@ -19,14 +20,23 @@ const (
 // This is the list of json paths that can be used by [jsondiff.Ignores].
 const (
 	totalTicketsJsonPath           string = "/totalTickets"
+	ticketsSoldPerHourJsonPath     string = "/ticketsSoldPerHour"
 	lastChangeModificationJsonPath string = "/lastChangeLogModification"
+	// derived from the start and end date times, which are compared already
+	dayJsonPath       string = "/day"
+	startHourJsonPath string = "/startHour"
+	endHourJsonPath   string = "/endHour"
 )
 
 var (
 	// EventJsonCmpIgnoredFields is the list of json paths that can be used by [jsondiff.Ignores].
 	EventJsonCmpIgnoredFields = []string{
 		totalTicketsJsonPath,
+		ticketsSoldPerHourJsonPath,
 		lastChangeModificationJsonPath,
+		dayJsonPath,
+		startHourJsonPath,
+		endHourJsonPath,
 	}
 )
 
@@ -48,6 +58,9 @@ type Event struct {
 	StartDateTime            time.Time    `json:"startDateTime"`
 	Duration                 float64      `json:"duration"`
 	EndDateTime              time.Time    `json:"endDateTime"`
+	Day                      Weekday      `json:"day"`       // set by HydrateConventionTime
+	StartHour                int64        `json:"startHour"` // set by HydrateConventionTime
+	EndHour                  int64        `json:"endHour"`   // set by HydrateConventionTime
 	GMNames                  string       `json:"gmNames"`
 	Website                  string       `json:"website"`
 	Email                    string       `json:"email"`
@@ -63,6 +76,7 @@ type Event struct {
 	SpecialCategory          Category     `json:"specialCategory"`
 	TicketsAvailable         int64        `json:"ticketsAvailable"`
 	TotalTickets             int64        `json:"totalTickets,omitempty"`
+	TicketsSoldPerHour       float64      `json:"ticketsSoldPerHour"` // sell-through velocity, recalculated on every update
 	LastModified             time.Time    `json:"lastModified"`
 	BggID                    string       `json:"bggId"`
 	BggRank                  int          `json:"bggRank,omitempty"`
@@ -287,16 +301,11 @@ func (e *Event) setTimeFieldFromString(field, value string) error {
 		return err
 	}
 
-	indy, err := time.LoadLocation("America/Indianapolis")
-	if err != nil {
-		return err
-	}
-
 	// Set the timezone to indy without converting the time
 	timeValue = time.Date(
 		timeValue.Year(), timeValue.Month(), timeValue.Day(),
 		timeValue.Hour(), timeValue.Minute(), timeValue.Second(), timeValue.Nanosecond(),
-		indy,
+		convention.Location,
 	)
 
 	switch field {
@@ -335,6 +344,16 @@ func (e *Event) setFloatFieldFromString(field, value string) error {
 	return nil
 }
 
+// SoldOut reports whether the event has no tickets left. Generic and free events
+// do not reserve event tickets, so they are never sold out.
+func (e *Event) SoldOut() bool {
+	if e.AttendeeRegistration == Generic || e.AttendeeRegistration == Free {
+		return false
+	}
+
+	return e.TicketsAvailable <= 0
+}
+
 // Externalize converts the internal Event shape into an api [gcbapi.Event]
 func (e *Event) Externalize() gcbapi.Event {
 	return gcbapi.Event{
@@ -360,6 +379,9 @@ func (e *Event) Externalize() gcbapi.Event {
 			StartDateTime:            e.StartDateTime,
 			Duration:                 e.Duration,
 			EndDateTime:              e.EndDateTime,
+			Day:                      string(e.Day),
+			StartHour:                e.StartHour,
+			EndHour:                  e.EndHour,
 			GMNames:                  e.GMNames,
 			Website:                  e.Website,
 			Email:                    e.Email,
@@ -375,6 +397,7 @@ func (e *Event) Externalize() gcbapi.Event {
 			SpecialCategory:          string(e.SpecialCategory),
 			TicketsAvailable:         e.TicketsAvailable,
 			TotalTickets:             e.TotalTickets,
+			TicketsSoldPerHour:       e.TicketsSoldPerHour,
 			LastModified:             e.LastModified,
 			BggID:                    e.BggID,
 			BggRank:                  e.BggRank,
@@ -383,6 +406,7 @@ func (e *Event) Externalize() gcbapi.Event {
 			Prize:                    e.Prize,
 			RulesComplexity:          e.RulesComplexity,
 			OriginalOrder:            e.OriginalOrder,
+			Deleted:                  e.Deleted,
 		},
 	}
 }
@@ -405,6 +429,9 @@ func FromExternal(e gcbapi.Event) (*Event, error) {
 		StartDateTime:            e.Attributes.StartDateTime,
 		Duration:                 e.Attributes.Duration,
 		EndDateTime:              e.Attributes.EndDateTime,
+		Day:                      Weekday(e.Attributes.Day),
+		StartHour:                e.Attributes.StartHour,
+		EndHour:                  e.Attributes.EndHour,
 		GMNames:                  e.Attributes.GMNames,
 		Website:                  e.Attributes.Website,
 		Email:                    e.Attributes.Email,
@@ -418,6 +445,7 @@ func FromExternal(e gcbapi.Event) (*Event, error) {
 		TableNumber:              e.Attributes.TableNumber,
 		TicketsAvailable:         e.Attributes.TicketsAvailable,
 		TotalTickets:             e.Attributes.TotalTickets,
+		TicketsSoldPerHour:       e.Attributes.TicketsSoldPerHour,
 		LastModified:             e.Attributes.LastModified,
 		BggID:                    e.Attributes.BggID,
 		BggRank:                  e.Attributes.BggRank,
@@ -426,6 +454,7 @@ func FromExternal(e gcbapi.Event) (*Event, error) {
 		Prize:                    e.Attributes.Prize,
 		RulesComplexity:          e.Attributes.RulesComplexity,
 		OriginalOrder:            e.Attributes.OriginalOrder,
+		Deleted:                  e.Attributes.Deleted,
 	}
 
 	if err := ValidateAgeGroup(e.Attributes.AgeRequired); err != nil {
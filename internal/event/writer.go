@@ -0,0 +1,275 @@
+package event
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/gencon_buddy_api/internal/convention"
+)
+
+// exportColumns are the headers and fields written for each event, in the
+// order of Gen Con's event file. Every header is one [headersToFields] understands,
+// so an exported file can be read back in by the [Reader]s.
+var exportColumns = []struct {
+	header string
+	field  string
+}{
+	{"Game ID", "game_id"},
+	{"Group", "group"},
+	{"Title", "title"},
+	{"Short Description", "short_description"},
+	{"Long Description", "long_description"},
+	{"Event Type", "event_type"},
+	{"Game System", "game_system"},
+	{"Rules Edition", "rules_edition"},
+	{"Minimum Players", "min_players"},
+	{"Maximum Players", "max_players"},
+	{"Age Required", "age_required"},
+	{"Experience Required", "experience_required"},
+	{"Materials Required", "materials_required"},
+	{"Materials Required Details", "materials_required_details"},
+	{"Start Date & Time", "start_date_time"},
+	{"Duration", "duration"},
+	{"End Date & Time", "end_date_time"},
+	{"GM Names", "gm_names"},
+	{"Website", "website"},
+	{"Email", "email"},
+	{"Tournament?", "tournament"},
+	{"Round Number", "round_number"},
+	{"Total Rounds", "total_rounds"},
+	{"Minimum Play Time", "minimum_play_time"},
+	{"Attendee Registration?", "attendee_registration"},
+	{"Cost $", "cost"},
+	{"Location", "location"},
+	{"Room Name", "room_name"},
+	{"Table Number", "table_number"},
+	{"Special Category", "special_category"},
+	{"Tickets Available", "tickets_available"},
+	{"Last Modified", "last_modified"},
+}
+
+// Writer writes out Events
+type Writer interface {
+	// WriteEvents writes the events after any previously written events
+	WriteEvents(context.Context, []*Event) error
+	// Close finishes the file, no events can be written after
+	Close() error
+}
+
+// CSVWriter writes events to a csv file in the Windows-1252 encoding the [CSVReader] expects.
+// Characters that cannot be encoded are replaced.
+type CSVWriter struct {
+	csvWriter *csv.Writer
+}
+
+// NewCSVWriter writes the header row to w and instantiates a CSVWriter
+func NewCSVWriter(w io.Writer) (*CSVWriter, error) {
+	encoder := encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder())
+	c := &CSVWriter{
+		csvWriter: csv.NewWriter(encoder.Writer(w)),
+	}
+
+	if err := c.csvWriter.Write(exportHeaders()); err != nil {
+		return nil, fmt.Errorf("failed to write the csv headers: %w", err)
+	}
+
+	return c, nil
+}
+
+// WriteEvents to the csv file, flushing them to the underlying writer
+func (c *CSVWriter) WriteEvents(ctx context.Context, events []*Event) error {
+	for _, e := range events {
+		if err := c.csvWriter.Write(e.exportRow()); err != nil {
+			return fmt.Errorf("failed to write event %s: %w", e.GameID, err)
+		}
+	}
+
+	c.csvWriter.Flush()
+	return c.csvWriter.Error()
+}
+
+// Close flushes any buffered events
+func (c *CSVWriter) Close() error {
+	c.csvWriter.Flush()
+	return c.csvWriter.Error()
+}
+
+// XLSXWriter writes events to a single sheet xlsx file.
+// The workbook is only written to the underlying writer on Close.
+type XLSXWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// NewXLSXWriter instantiates an XLSXWriter with the header row written
+func NewXLSXWriter(w io.Writer) (*XLSXWriter, error) {
+	f := excelize.NewFile()
+
+	stream, err := f.NewStreamWriter(f.GetSheetName(0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the xlsx stream writer: %w", err)
+	}
+
+	x := &XLSXWriter{
+		w:      w,
+		file:   f,
+		stream: stream,
+	}
+
+	if err := x.writeRow(exportHeaders()); err != nil {
+		return nil, fmt.Errorf("failed to write the xlsx headers: %w", err)
+	}
+
+	return x, nil
+}
+
+// WriteEvents to the sheet
+func (x *XLSXWriter) WriteEvents(ctx context.Context, events []*Event) error {
+	for _, e := range events {
+		if err := x.writeRow(e.exportRow()); err != nil {
+			return fmt.Errorf("failed to write event %s: %w", e.GameID, err)
+		}
+	}
+
+	return nil
+}
+
+// Close writes the workbook to the underlying writer
+func (x *XLSXWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to flush the xlsx sheet: %w", err)
+	}
+
+	if err := x.file.Write(x.w); err != nil {
+		return fmt.Errorf("failed to write the xlsx file: %w", err)
+	}
+
+	return nil
+}
+
+func (x *XLSXWriter) writeRow(values []string) error {
+	x.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	row := make([]any, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+
+	return x.stream.SetRow(cell, row)
+}
+
+func exportHeaders() []string {
+	headers := make([]string, len(exportColumns))
+	for i, c := range exportColumns {
+		headers[i] = c.header
+	}
+
+	return headers
+}
+
+func (e *Event) exportRow() []string {
+	row := make([]string, len(exportColumns))
+	for i, c := range exportColumns {
+		row[i] = e.fieldString(c.field)
+	}
+
+	return row
+}
+
+// fieldString formats a field the way [Event.SetFieldFromString] parses it.
+// Times are written in Indy time.
+func (e *Event) fieldString(field string) string {
+	switch field {
+	case "game_id":
+		return e.GameID
+	case "group":
+		return e.Group
+	case "title":
+		return e.Title
+	case "short_description":
+		return e.ShortDescription
+	case "long_description":
+		return e.LongDescription
+	case "event_type":
+		return string(e.EventType)
+	case "game_system":
+		return e.GameSystem
+	case "rules_edition":
+		return e.RulesEdition
+	case "min_players":
+		return strconv.FormatInt(e.MinPlayers, 10)
+	case "max_players":
+		return strconv.FormatInt(e.MaxPlayers, 10)
+	case "age_required":
+		return string(e.AgeRequired)
+	case "experience_required":
+		return string(e.ExperienceRequired)
+	case "materials_required":
+		return e.MaterialsRequired
+	case "materials_required_details":
+		return e.MaterialsRequiredDetails
+	case "start_date_time":
+		return formatIndyTime(e.StartDateTime, dateTimeFormat)
+	case "duration":
+		return strconv.FormatFloat(e.Duration, 'f', -1, 64)
+	case "end_date_time":
+		return formatIndyTime(e.EndDateTime, dateTimeFormat)
+	case "gm_names":
+		return e.GMNames
+	case "website":
+		return e.Website
+	case "email":
+		return e.Email
+	case "tournament":
+		return e.Tournament
+	case "round_number":
+		return strconv.FormatInt(e.RoundNumber, 10)
+	case "total_rounds":
+		return strconv.FormatInt(e.TotalRounds, 10)
+	case "minimum_play_time":
+		return strconv.FormatFloat(e.MinimumPlayTime, 'f', -1, 64)
+	case "attendee_registration":
+		return string(e.AttendeeRegistration)
+	case "cost":
+		return strconv.FormatFloat(e.Cost, 'f', -1, 64)
+	case "location":
+		return e.Location
+	case "room_name":
+		return e.RoomName
+	case "table_number":
+		return e.TableNumber
+	case "special_category":
+		return string(e.SpecialCategory)
+	case "tickets_available":
+		return strconv.FormatInt(e.TicketsAvailable, 10)
+	case "last_modified":
+		return formatIndyTime(e.LastModified, lastModifiedFormat)
+	default:
+		return ""
+	}
+}
+
+func formatIndyTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.In(convention.Location).Format(layout)
+}
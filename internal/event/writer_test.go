@@ -0,0 +1,120 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestEvents(t *testing.T) []*Event {
+	indy, err := time.LoadLocation("America/Indianapolis")
+	require.NoError(t, err)
+
+	start := time.Date(2025, 7, 31, 18, 30, 0, 0, indy)
+	return []*Event{
+		{
+			GameID:               "RPG25ND286543",
+			Group:                "Dragon Guild",
+			Title:                `Café "Crawl", Part 1`,
+			ShortDescription:     "A short\nadventure",
+			EventType:            RPG,
+			GameSystem:           "Dungeons & Dragons",
+			MinPlayers:           3,
+			MaxPlayers:           6,
+			AgeRequired:          Teen,
+			ExperienceRequired:   None,
+			StartDateTime:        start,
+			Duration:             4.5,
+			EndDateTime:          start.Add(4*time.Hour + 30*time.Minute),
+			AttendeeRegistration: Open,
+			Cost:                 4,
+			Location:             "ICC",
+			RoomName:             "Room 101",
+			TableNumber:          "12",
+			SpecialCategory:      Official,
+			TicketsAvailable:     2,
+			LastModified:         time.Date(2025, 6, 1, 0, 0, 0, 0, indy),
+		},
+		{
+			GameID:               "BGM25ND000001",
+			Title:                "Board Game",
+			EventType:            BGM,
+			StartDateTime:        start.UTC(),
+			EndDateTime:          start.Add(time.Hour).UTC(),
+			Duration:             1,
+			AttendeeRegistration: Generic,
+		},
+	}
+}
+
+func TestExportColumnsAreReadable(t *testing.T) {
+	for _, c := range exportColumns {
+		field, ok := headersToFields[strings.ToLower(c.header)]
+		require.True(t, ok, "header %q is not readable", c.header)
+		require.Equal(t, c.field, field)
+	}
+}
+
+func TestCSVWriter_RoundTrip(t *testing.T) {
+	events := exportTestEvents(t)
+
+	path := filepath.Join(t.TempDir(), "events.csv")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	w, err := NewCSVWriter(f)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteEvents(context.Background(), events[:1]))
+	require.NoError(t, w.WriteEvents(context.Background(), events[1:]))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	r, err := NewCSVReader(zerolog.Nop(), path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	read, err := r.ReadEvents(context.Background())
+	require.NoError(t, err)
+	requireSameEvents(t, events, read)
+}
+
+func TestXLSXWriter_RoundTrip(t *testing.T) {
+	events := exportTestEvents(t)
+
+	var buff bytes.Buffer
+	w, err := NewXLSXWriter(&buff)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteEvents(context.Background(), events))
+	require.NoError(t, w.Close())
+
+	r, err := NewXLSXReader(zerolog.Nop(), XLSXFileOptions{Reader: &buff})
+	require.NoError(t, err)
+
+	read, err := r.ReadEvents(context.Background())
+	require.NoError(t, err)
+	requireSameEvents(t, events, read)
+}
+
+func requireSameEvents(t *testing.T, want, got []*Event) {
+	t.Helper()
+
+	require.Len(t, got, len(want))
+	for i := range want {
+		w, g := *want[i], *got[i]
+		require.True(t, w.StartDateTime.Equal(g.StartDateTime), "start %s != %s", w.StartDateTime, g.StartDateTime)
+		require.True(t, w.EndDateTime.Equal(g.EndDateTime), "end %s != %s", w.EndDateTime, g.EndDateTime)
+		require.True(t, w.LastModified.Equal(g.LastModified), "last modified %s != %s", w.LastModified, g.LastModified)
+
+		w.StartDateTime, g.StartDateTime = time.Time{}, time.Time{}
+		w.EndDateTime, g.EndDateTime = time.Time{}, time.Time{}
+		w.LastModified, g.LastModified = time.Time{}, time.Time{}
+		require.Equal(t, w, g)
+	}
+}
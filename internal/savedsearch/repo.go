@@ -0,0 +1,355 @@
+package savedsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/rs/zerolog"
+)
+
+const bulkCreateMeta = `{ "create": { "_index": "%s", "_id": "%s" } }`
+
+// Repo controls talking to the OpenSearch cluster for saved searches and their notifications
+type Repo struct {
+	logger            *zerolog.Logger
+	client            *opensearch.Client
+	batchSize         int
+	savedSearchIndex  string
+	notificationIndex string
+}
+
+// NewRepo instantiates a new Repo
+func NewRepo(logger *zerolog.Logger, client *opensearch.Client, batchSize int, savedSearchIndex, notificationIndex string) *Repo {
+	return &Repo{
+		logger:            logger,
+		client:            client,
+		batchSize:         batchSize,
+		savedSearchIndex:  savedSearchIndex,
+		notificationIndex: notificationIndex,
+	}
+}
+
+// Create stores a new saved search
+func (r *Repo) Create(ctx context.Context, s *SavedSearch) error {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal saved search %s: %w", s.ID, err)
+	}
+
+	req := opensearchapi.CreateRequest{
+		Index:      r.savedSearchIndex,
+		DocumentID: s.ID,
+		Body:       bytes.NewReader(body),
+		Refresh:    "true",
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close create saved search response body")
+		}
+	}()
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("create saved search request failed. Raw response: %s", resp.String())
+		return fmt.Errorf("failed create saved search request %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Get fetches a saved search, returning nil if it does not exist
+func (r *Repo) Get(ctx context.Context, id string) (*SavedSearch, error) {
+	req := opensearchapi.GetRequest{
+		Index:      r.savedSearchIndex,
+		DocumentID: id,
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close get saved search response body")
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("get saved search request failed. Raw response: %s", resp.String())
+		return nil, fmt.Errorf("failed get saved search request %d", resp.StatusCode)
+	}
+
+	var (
+		response struct {
+			Found       bool         `json:"found"`
+			SavedSearch *SavedSearch `json:"_source,omitempty"`
+		}
+		buff = bytes.NewBuffer([]byte{})
+	)
+
+	if _, err := buff.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read get saved search response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal get saved search response: %w", err)
+	}
+
+	if !response.Found {
+		return nil, nil
+	}
+
+	return response.SavedSearch, nil
+}
+
+// Delete removes a saved search, returning false if it did not exist.
+// Its notifications are kept.
+func (r *Repo) Delete(ctx context.Context, id string) (bool, error) {
+	req := opensearchapi.DeleteRequest{
+		Index:      r.savedSearchIndex,
+		DocumentID: id,
+		Refresh:    "true",
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close delete saved search response body")
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("delete saved search request failed. Raw response: %s", resp.String())
+		return false, fmt.Errorf("failed delete saved search request %d", resp.StatusCode)
+	}
+
+	return true, nil
+}
+
+// All fetches every saved search, paging through them in batches
+func (r *Repo) All(ctx context.Context) ([]*SavedSearch, error) {
+	if r.batchSize <= 0 {
+		return nil, fmt.Errorf("batch size cannot be less than 1, got %d", r.batchSize)
+	}
+
+	var (
+		all         []*SavedSearch
+		searchAfter []any
+	)
+
+	for {
+		body := map[string]any{
+			"size":  r.batchSize,
+			"query": map[string]any{"match_all": map[string]any{}},
+			"sort": []any{
+				map[string]any{"id": map[string]any{"order": "asc"}},
+			},
+		}
+
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+
+		var response struct {
+			Hits struct {
+				Hits []struct {
+					SavedSearch *SavedSearch `json:"_source,omitempty"`
+					Sort        []any        `json:"sort,omitempty"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+
+		if err := r.search(ctx, r.savedSearchIndex, body, &response); err != nil {
+			return nil, err
+		}
+
+		for _, h := range response.Hits.Hits {
+			all = append(all, h.SavedSearch)
+		}
+
+		hits := response.Hits.Hits
+		if len(hits) < r.batchSize {
+			return all, nil
+		}
+
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+// CreateNotifications stores the notifications, returning any per notification write errors
+func (r *Repo) CreateNotifications(ctx context.Context, notifications ...*Notification) ([]error, error) {
+	if len(notifications) == 0 {
+		return nil, nil
+	}
+
+	var (
+		body    strings.Builder
+		docErrs []error
+	)
+
+	for _, n := range notifications {
+		docJson, err := json.Marshal(n)
+		if err != nil {
+			docErrs = append(docErrs, fmt.Errorf("failed to marshal notification %s: %w", n.ID, err))
+			continue
+		}
+
+		body.WriteString(fmt.Sprintf(bulkCreateMeta, r.notificationIndex, n.ID) + "\n")
+		body.Write(docJson)
+		body.WriteString("\n")
+	}
+
+	req := opensearchapi.BulkRequest{
+		Index: r.notificationIndex,
+		Body:  strings.NewReader(body.String()),
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return docErrs, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close notification bulk response body")
+		}
+	}()
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("notification bulk request failed. Raw response: %s", resp.String())
+		return docErrs, fmt.Errorf("failed notification bulk request %d", resp.StatusCode)
+	}
+
+	var (
+		response struct {
+			Errors bool `json:"errors"`
+			Items  []map[string]struct {
+				ID    string `json:"_id"`
+				Error *struct {
+					Type   string `json:"type"`
+					Reason string `json:"reason"`
+				} `json:"error,omitempty"`
+			} `json:"items"`
+		}
+		buff = bytes.NewBuffer([]byte{})
+	)
+
+	if _, err := buff.ReadFrom(resp.Body); err != nil {
+		return docErrs, fmt.Errorf("failed to read notification bulk response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), &response); err != nil {
+		return docErrs, fmt.Errorf("failed to unmarshal notification bulk response: %w", err)
+	}
+
+	if !response.Errors {
+		return docErrs, nil
+	}
+
+	for _, item := range response.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				docErrs = append(docErrs, fmt.Errorf("notification %s %s: %s", result.ID, result.Error.Type, result.Error.Reason))
+			}
+		}
+	}
+
+	return docErrs, nil
+}
+
+// ListNotifications lists the notifications of a saved search, newest first
+func (r *Repo) ListNotifications(ctx context.Context, req ListNotificationsRequest) ([]*Notification, error) {
+	if req.Limit <= 0 {
+		return nil, fmt.Errorf("limit cannot be less than 1, got %d", req.Limit)
+	}
+
+	body := map[string]any{
+		"size": req.Limit,
+		"query": map[string]any{
+			"term": map[string]any{"savedSearchId": req.SavedSearchID},
+		},
+		"sort": []any{
+			map[string]any{"date": map[string]any{"order": "desc"}},
+			map[string]any{"id": map[string]any{"order": "desc"}},
+		},
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Notification *Notification `json:"_source,omitempty"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := r.search(ctx, r.notificationIndex, body, &response); err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*Notification, len(response.Hits.Hits))
+	for i, h := range response.Hits.Hits {
+		notifications[i] = h.Notification
+	}
+
+	return notifications, nil
+}
+
+func (r *Repo) search(ctx context.Context, index string, body map[string]any, response any) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search request: %w", err)
+	}
+
+	r.logger.Debug().Msgf("Performing search request on %s: %s", index, bodyBytes)
+
+	osReq := opensearchapi.SearchRequest{
+		Index: []string{index},
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	osResp, err := osReq.Do(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := osResp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close search response body")
+		}
+	}()
+
+	if osResp.IsError() {
+		r.logger.Error().Msgf("search request failed. Raw response: %s", osResp.String())
+		return fmt.Errorf("failed search request %d", osResp.StatusCode)
+	}
+
+	buff := bytes.NewBuffer([]byte{})
+	if _, err := buff.ReadFrom(osResp.Body); err != nil {
+		return fmt.Errorf("failed to read search response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), response); err != nil {
+		return fmt.Errorf("failed to unmarshal search response: %w", err)
+	}
+
+	return nil
+}
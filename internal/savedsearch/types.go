@@ -0,0 +1,145 @@
+package savedsearch
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gencon_buddy_api/internal/event"
+	"github.com/gencon_buddy_api/internal/search"
+)
+
+// SavedSearch is an event search query stored under an ID,
+// so new and updated events matching it can be reported after each change log.
+type SavedSearch struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Query is the raw query string of an event search, such as "gameSystem=Pathfinder&cost=[,4]"
+	Query string `json:"query"`
+	Date  string `json:"date"`
+}
+
+// Notification records the events from a single change log that matched a saved search.
+type Notification struct {
+	ID            string   `json:"id"`
+	SavedSearchID string   `json:"savedSearchId"`
+	ChangeLogID   string   `json:"changeLogId"`
+	Date          string   `json:"date"`
+	CreatedEvents []string `json:"createdEvents"`
+	UpdatedEvents []string `json:"updatedEvents"`
+}
+
+// ignoredParams are search query parameters that page or shape the results
+// rather than filter them, so they have no effect on a saved search.
+var ignoredParams = map[string]struct{}{
+	"limit":     {},
+	"page":      {},
+	"cursor":    {},
+	"sort":      {},
+	"facets":    {},
+	"highlight": {},
+}
+
+// unsupportedParams are search query parameters that filter the results, but are not search terms.
+// Conflicts are with the events of a schedule at the time of the search, which a saved search cannot follow.
+var unsupportedParams = map[string]struct{}{
+	"excludeConflictsWith": {},
+	"conflictBuffer":       {},
+}
+
+// NewSavedSearch validates the query and instantiates a [SavedSearch]
+// with a UUID for the ID and a date timestamp of now.
+func NewSavedSearch(name, query string) (*SavedSearch, error) {
+	s := &SavedSearch{
+		ID:    uuid.Must(uuid.NewV7()).String(),
+		Name:  strings.TrimSpace(name),
+		Query: strings.TrimPrefix(strings.TrimSpace(query), "?"),
+		Date:  time.Now().Format(time.RFC3339),
+	}
+
+	terms, err := s.Terms()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("a saved search requires at least 1 search filter")
+	}
+
+	return s, nil
+}
+
+// NewNotification instantiates a [Notification] with a UUID for the ID
+// and a date timestamp of now.
+func NewNotification(savedSearchID, changeLogID string) *Notification {
+	return &Notification{
+		ID:            uuid.Must(uuid.NewV7()).String(),
+		SavedSearchID: savedSearchID,
+		ChangeLogID:   changeLogID,
+		Date:          time.Now().Format(time.RFC3339),
+		CreatedEvents: []string{},
+		UpdatedEvents: []string{},
+	}
+}
+
+// Terms parses the search filters of the query. Parameters that only page
+// or sort the search are ignored, while conflict exclusion is rejected.
+func (s *SavedSearch) Terms() ([]search.Term, error) {
+	query, err := url.ParseQuery(s.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid saved search query: %w", err)
+	}
+
+	var terms []search.Term
+	for param, values := range query {
+		if _, ok := ignoredParams[param]; ok {
+			continue
+		}
+
+		if _, ok := unsupportedParams[param]; ok {
+			return nil, fmt.Errorf("search query param %s is not supported by saved searches", param)
+		}
+
+		term, err := event.NewSearchField(param, strings.Join(values, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid search query param %s: %w", param, err)
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// SearchRequest builds the search for the saved search's matches among the given events.
+// Soft-deleted events never match.
+func (s *SavedSearch) SearchRequest(gameIDs []string, limit int) (event.SearchRequest, error) {
+	terms, err := s.Terms()
+	if err != nil {
+		return event.SearchRequest{}, err
+	}
+
+	idTerm, err := search.NewKeywordSlice(string(event.GameID), gameIDs)
+	if err != nil {
+		return event.SearchRequest{}, err
+	}
+
+	visibleTerm, err := event.NewSearchField(string(event.Deleted), "false")
+	if err != nil {
+		return event.SearchRequest{}, err
+	}
+
+	return event.SearchRequest{
+		Terms: append(terms, idTerm, visibleTerm),
+		Limit: limit,
+	}, nil
+}
+
+// ListNotificationsRequest fetches the most recent notifications of a saved search.
+type ListNotificationsRequest struct {
+	SavedSearchID string
+	Limit         int
+}
@@ -0,0 +1,79 @@
+package savedsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSavedSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "filters",
+			query:     "gameSystem=Pathfinder&cost=[,4]",
+			wantQuery: "gameSystem=Pathfinder&cost=[,4]",
+		},
+		{
+			name:      "leading question mark is trimmed",
+			query:     "?eventType=RPG&sort=title.asc",
+			wantQuery: "eventType=RPG&sort=title.asc",
+		},
+		{
+			name:    "only paging params",
+			query:   "limit=10&page=2",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			query:   "bogus=1",
+			wantErr: true,
+		},
+		{
+			name:    "conflict exclusion",
+			query:   "eventType=RPG&excludeConflictsWith=RPG25ND286543&conflictBuffer=30",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			query:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSavedSearch(" Saturday RPGs ", tt.query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, s.ID)
+			require.Equal(t, "Saturday RPGs", s.Name)
+			require.Equal(t, tt.wantQuery, s.Query)
+		})
+	}
+}
+
+func TestSavedSearch_SearchRequest(t *testing.T) {
+	s := &SavedSearch{ID: "1", Query: "eventType=RPG&limit=5&sort=title.asc"}
+
+	req, err := s.SearchRequest([]string{"RPG25ND1", "RPG25ND2"}, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, req.Limit)
+	require.Len(t, req.Terms, 3)
+
+	idQuery, err := req.Terms[1].ToQuery()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"terms": map[string]any{"gameId": []string{"RPG25ND1", "RPG25ND2"}}}, idQuery)
+
+	visibleQuery, err := req.Terms[2].ToQuery()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"term": map[string]any{"deleted": "false"}}, visibleQuery)
+}
@@ -0,0 +1,124 @@
+package schedule
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+// DefaultTransitionWindow is how soon one event must start after another ends
+// for the walk between different locations to be considered tight.
+const DefaultTransitionWindow = 15 * time.Minute
+
+// Overlap is a pair of scheduled events whose times overlap.
+type Overlap struct {
+	First  *event.Event
+	Second *event.Event
+	// Duration is how long both events are running at the same time
+	Duration time.Duration
+}
+
+// Transition is a pair of back to back events in different locations.
+type Transition struct {
+	From *event.Event
+	To   *event.Event
+	// Gap is the time between the end of From and the start of To
+	Gap time.Duration
+}
+
+// UnavailableReason explains why a scheduled event cannot be attended.
+type UnavailableReason string
+
+const (
+	Deleted UnavailableReason = "deleted"
+	SoldOut UnavailableReason = "soldOut"
+)
+
+// Unavailable is a scheduled event that cannot be attended.
+type Unavailable struct {
+	Event  *event.Event
+	Reason UnavailableReason
+}
+
+// Analysis is the result of analyzing a schedule of events.
+type Analysis struct {
+	Overlaps    []Overlap
+	Transitions []Transition
+	Unavailable []Unavailable
+	// TotalCost is the ticket cost of every event that has not been deleted
+	TotalCost float64
+}
+
+// Analyze finds the conflicts in a schedule of events.
+// Events are compared in start time order, so overlaps and transitions
+// list the earlier event first.
+func Analyze(events []*event.Event, transitionWindow time.Duration) Analysis {
+	sorted := make([]*event.Event, 0, len(events))
+	for _, e := range events {
+		if e != nil {
+			sorted = append(sorted, e)
+		}
+	}
+
+	slices.SortFunc(sorted, func(a, b *event.Event) int {
+		if c := a.StartDateTime.Compare(b.StartDateTime); c != 0 {
+			return c
+		}
+		if c := a.EndDateTime.Compare(b.EndDateTime); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.GameID, b.GameID)
+	})
+
+	var analysis Analysis
+	for i, a := range sorted {
+		switch {
+		case a.Deleted:
+			analysis.Unavailable = append(analysis.Unavailable, Unavailable{Event: a, Reason: Deleted})
+		case a.SoldOut():
+			analysis.Unavailable = append(analysis.Unavailable, Unavailable{Event: a, Reason: SoldOut})
+		}
+
+		if !a.Deleted {
+			analysis.TotalCost += a.Cost
+		}
+
+		for _, b := range sorted[i+1:] {
+			// events are sorted by start, so nothing after b can overlap or follow a closely either
+			if b.StartDateTime.After(a.EndDateTime.Add(transitionWindow)) {
+				break
+			}
+
+			if b.StartDateTime.Before(a.EndDateTime) {
+				end := a.EndDateTime
+				if b.EndDateTime.Before(end) {
+					end = b.EndDateTime
+				}
+
+				analysis.Overlaps = append(analysis.Overlaps, Overlap{
+					First:    a,
+					Second:   b,
+					Duration: end.Sub(b.StartDateTime),
+				})
+				continue
+			}
+
+			if !sameLocation(a.Location, b.Location) {
+				analysis.Transitions = append(analysis.Transitions, Transition{
+					From: a,
+					To:   b,
+					Gap:  b.StartDateTime.Sub(a.EndDateTime),
+				})
+			}
+		}
+	}
+
+	return analysis
+}
+
+func sameLocation(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
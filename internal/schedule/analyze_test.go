@@ -0,0 +1,55 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gencon_buddy_api/internal/event"
+)
+
+func TestAnalyze(t *testing.T) {
+	day := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+	newEvent := func(id string, startHour, endHour float64, location string) *event.Event {
+		return &event.Event{
+			GameID:               id,
+			StartDateTime:        day.Add(time.Duration(startHour * float64(time.Hour))),
+			EndDateTime:          day.Add(time.Duration(endHour * float64(time.Hour))),
+			Location:             location,
+			Cost:                 4,
+			TicketsAvailable:     2,
+			AttendeeRegistration: event.Open,
+		}
+	}
+
+	morning := newEvent("A", 9, 11, "ICC")
+	brunch := newEvent("B", 10, 12, "ICC")
+	walk := newEvent("C", 12.1, 14, "JW Marriott")
+	sameBuilding := newEvent("D", 14, 15, "jw marriott ")
+	later := newEvent("E", 18, 20, "Lucas Oil")
+
+	deleted := newEvent("F", 21, 22, "ICC")
+	deleted.Deleted = true
+
+	soldOut := newEvent("G", 22, 23, "ICC")
+	soldOut.TicketsAvailable = 0
+
+	generic := newEvent("H", 23, 24, "ICC")
+	generic.TicketsAvailable = 0
+	generic.AttendeeRegistration = event.Generic
+
+	analysis := Analyze([]*event.Event{later, soldOut, walk, morning, nil, generic, deleted, brunch, sameBuilding}, DefaultTransitionWindow)
+
+	require.Equal(t, []Overlap{{First: morning, Second: brunch, Duration: time.Hour}}, analysis.Overlaps)
+	require.Equal(t, []Transition{{From: brunch, To: walk, Gap: 6 * time.Minute}}, analysis.Transitions)
+	require.Equal(t, []Unavailable{
+		{Event: deleted, Reason: Deleted},
+		{Event: soldOut, Reason: SoldOut},
+	}, analysis.Unavailable)
+	require.Equal(t, float64(28), analysis.TotalCost)
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	require.Equal(t, Analysis{}, Analyze(nil, DefaultTransitionWindow))
+}
@@ -82,3 +82,17 @@ func (b *Bool) ToQuery() (any, error) {
 		"bool": boolQuery,
 	}, nil
 }
+
+// HighlightFields implements [Highlightable] with the fields of every
+// must and should term that is highlightable. Must not terms never match
+// a returned document, so they are skipped.
+func (b *Bool) HighlightFields() []string {
+	var fields []string
+	for _, t := range append(append([]Term{}, b.must...), b.should...) {
+		if h, ok := t.(Highlightable); ok {
+			fields = append(fields, h.HighlightFields()...)
+		}
+	}
+
+	return fields
+}
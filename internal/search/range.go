@@ -36,3 +36,23 @@ func NewRange(v string) (Range, error) {
 
 	return r, nil
 }
+
+// Min is the lower bound of the range. Empty when the range is unbounded below.
+func (r Range) Min() string {
+	return r.min
+}
+
+// Max is the upper bound of the range. Empty when the range is unbounded above.
+func (r Range) Max() string {
+	return r.max
+}
+
+// InclusiveMin is true when the lower bound is included in the range.
+func (r Range) InclusiveMin() bool {
+	return r.inclusiveMin
+}
+
+// InclusiveMax is true when the upper bound is included in the range.
+func (r Range) InclusiveMax() bool {
+	return r.inclusiveMax
+}
@@ -5,3 +5,9 @@ package search
 type Term interface {
 	ToQuery() (any, error)
 }
+
+// Highlightable is implemented by terms that match against text fields,
+// so search results can highlight why a document matched.
+type Highlightable interface {
+	HighlightFields() []string
+}
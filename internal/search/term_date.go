@@ -3,6 +3,8 @@ package search
 import (
 	"fmt"
 	"time"
+
+	"github.com/gencon_buddy_api/internal/convention"
 )
 
 // Date implements the Term interface to support
@@ -84,12 +86,7 @@ func convertDate(dateStr string) (string, error) {
 		return "", err
 	}
 
-	indy, err := time.LoadLocation("America/Indianapolis")
-	if err != nil {
-		return "", fmt.Errorf("failed to load indy time zone: %w", err)
-	}
-
-	dateTime = dateTime.In(indy)
+	dateTime = dateTime.In(convention.Location)
 
 	return dateTime.Format(time.RFC3339), nil
 }
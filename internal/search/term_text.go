@@ -10,6 +10,7 @@ import (
 type Text struct {
 	field  string
 	values []string
+	phrase bool
 }
 
 func NewText(field, vals string) (Text, error) {
@@ -28,6 +29,20 @@ func NewText(field, vals string) (Text, error) {
 	return text, nil
 }
 
+// NewTextPhrase creates a text term that only matches the value's words
+// next to each other in the same order. The value is not split by ,
+func NewTextPhrase(field, val string) (Text, error) {
+	if field == "" {
+		return Text{}, fmt.Errorf("cannot create a text term without a field")
+	}
+
+	if val == "" {
+		return Text{}, fmt.Errorf("cannot create a text term on %s without fields", field)
+	}
+
+	return Text{field: field, values: []string{val}, phrase: true}, nil
+}
+
 func (t Text) ToQuery() (any, error) {
 	if t.field == "" {
 		return nil, fmt.Errorf("cannot create a text query without a field")
@@ -37,7 +52,18 @@ func (t Text) ToQuery() (any, error) {
 		return nil, fmt.Errorf("cannot create a text query on %s without and values", t.field)
 	}
 
+	if t.phrase {
+		return map[string]any{
+			"match_phrase": map[string]any{t.field: t.values[0]},
+		}, nil
+	}
+
 	return map[string]any{
 		"match": map[string]any{t.field: strings.Join(t.values, " ")},
 	}, nil
 }
+
+// HighlightFields implements [Highlightable]
+func (t Text) HighlightFields() []string {
+	return []string{t.field}
+}
@@ -0,0 +1,275 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/rs/zerolog"
+)
+
+const bulkIndexMeta = `{ "index": { "_index": "%s", "_id": "%s" } }`
+
+// Repo controls talking to the OpenSearch cluster for the ticket snapshot time series
+type Repo struct {
+	logger        *zerolog.Logger
+	client        *opensearch.Client
+	snapshotIndex string
+}
+
+// NewRepo instantiates a new Repo
+func NewRepo(logger *zerolog.Logger, client *opensearch.Client, snapshotIndex string) *Repo {
+	return &Repo{
+		logger:        logger,
+		client:        client,
+		snapshotIndex: snapshotIndex,
+	}
+}
+
+// WriteSnapshots stores the snapshots, returning any per snapshot write errors
+func (r *Repo) WriteSnapshots(ctx context.Context, snapshots ...*Snapshot) ([]error, error) {
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	var (
+		body    strings.Builder
+		docErrs []error
+	)
+
+	for _, s := range snapshots {
+		docJson, err := json.Marshal(s)
+		if err != nil {
+			docErrs = append(docErrs, fmt.Errorf("failed to marshal ticket snapshot %s: %w", s.ID, err))
+			continue
+		}
+
+		body.WriteString(fmt.Sprintf(bulkIndexMeta, r.snapshotIndex, s.ID) + "\n")
+		body.Write(docJson)
+		body.WriteString("\n")
+	}
+
+	req := opensearchapi.BulkRequest{
+		Index: r.snapshotIndex,
+		Body:  strings.NewReader(body.String()),
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return docErrs, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close ticket snapshot bulk response body")
+		}
+	}()
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("ticket snapshot bulk request failed. Raw response: %s", resp.String())
+		return docErrs, fmt.Errorf("failed ticket snapshot bulk request %d", resp.StatusCode)
+	}
+
+	var (
+		response struct {
+			Errors bool `json:"errors"`
+			Items  []map[string]struct {
+				ID    string `json:"_id"`
+				Error *struct {
+					Type   string `json:"type"`
+					Reason string `json:"reason"`
+				} `json:"error,omitempty"`
+			} `json:"items"`
+		}
+		buff = bytes.NewBuffer([]byte{})
+	)
+
+	if _, err := buff.ReadFrom(resp.Body); err != nil {
+		return docErrs, fmt.Errorf("failed to read ticket snapshot bulk response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), &response); err != nil {
+		return docErrs, fmt.Errorf("failed to unmarshal ticket snapshot bulk response: %w", err)
+	}
+
+	if !response.Errors {
+		return docErrs, nil
+	}
+
+	for _, item := range response.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				docErrs = append(docErrs, fmt.Errorf("ticket snapshot %s %s: %s", result.ID, result.Error.Type, result.Error.Reason))
+			}
+		}
+	}
+
+	return docErrs, nil
+}
+
+// History fetches the latest limit snapshots of an event, sorted oldest first
+func (r *Repo) History(ctx context.Context, gameID string, limit int) ([]*Snapshot, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit cannot be less than 1, got %d", limit)
+	}
+
+	body := map[string]any{
+		"size": limit,
+		"query": map[string]any{
+			"term": map[string]any{"gameId": gameID},
+		},
+		"sort": []any{
+			map[string]any{"date": map[string]any{"order": "desc"}},
+			map[string]any{"changeLogId": map[string]any{"order": "desc"}},
+		},
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Snapshot *Snapshot `json:"_source,omitempty"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := r.search(ctx, body, &response); err != nil {
+		return nil, err
+	}
+
+	history := make([]*Snapshot, len(response.Hits.Hits))
+	for i, h := range response.Hits.Hits {
+		history[i] = h.Snapshot
+	}
+
+	slices.Reverse(history)
+
+	return history, nil
+}
+
+// Baselines finds the [Baseline] snapshot of each event as of now, keyed by game id.
+// Events without any snapshots are left out.
+func (r *Repo) Baselines(ctx context.Context, gameIDs []string, now time.Time) (map[string]*Snapshot, error) {
+	if len(gameIDs) == 0 {
+		return map[string]*Snapshot{}, nil
+	}
+
+	windowStart := now.Add(-VelocityWindow).Format(time.RFC3339)
+
+	body := map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"terms": map[string]any{"gameId": gameIDs},
+		},
+		"aggs": map[string]any{
+			"games": map[string]any{
+				"terms": map[string]any{
+					"field": "gameId",
+					"size":  len(gameIDs),
+				},
+				"aggs": map[string]any{
+					"beforeWindow": map[string]any{
+						"filter": map[string]any{
+							"range": map[string]any{"date": map[string]any{"lte": windowStart}},
+						},
+						"aggs": map[string]any{
+							"latest": map[string]any{
+								"top_hits": map[string]any{
+									"size": 1,
+									"sort": []any{map[string]any{"date": map[string]any{"order": "desc"}}},
+								},
+							},
+						},
+					},
+					"oldest": map[string]any{
+						"top_hits": map[string]any{
+							"size": 1,
+							"sort": []any{map[string]any{"date": map[string]any{"order": "asc"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	type topHits struct {
+		Hits struct {
+			Hits []struct {
+				Snapshot *Snapshot `json:"_source,omitempty"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	var response struct {
+		Aggregations struct {
+			Games struct {
+				Buckets []struct {
+					Key          string `json:"key"`
+					BeforeWindow struct {
+						Latest topHits `json:"latest"`
+					} `json:"beforeWindow"`
+					Oldest topHits `json:"oldest"`
+				} `json:"buckets"`
+			} `json:"games"`
+		} `json:"aggregations"`
+	}
+
+	if err := r.search(ctx, body, &response); err != nil {
+		return nil, err
+	}
+
+	baselines := make(map[string]*Snapshot, len(response.Aggregations.Games.Buckets))
+	for _, b := range response.Aggregations.Games.Buckets {
+		if latest := b.BeforeWindow.Latest.Hits.Hits; len(latest) > 0 {
+			baselines[b.Key] = latest[0].Snapshot
+		} else if oldest := b.Oldest.Hits.Hits; len(oldest) > 0 {
+			baselines[b.Key] = oldest[0].Snapshot
+		}
+	}
+
+	return baselines, nil
+}
+
+func (r *Repo) search(ctx context.Context, body map[string]any, response any) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search request: %w", err)
+	}
+
+	r.logger.Debug().Msgf("Performing search request on %s: %s", r.snapshotIndex, bodyBytes)
+
+	osReq := opensearchapi.SearchRequest{
+		Index: []string{r.snapshotIndex},
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	osResp, err := osReq.Do(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := osResp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close search response body")
+		}
+	}()
+
+	if osResp.IsError() {
+		r.logger.Error().Msgf("search request failed. Raw response: %s", osResp.String())
+		return fmt.Errorf("failed search request %d", osResp.StatusCode)
+	}
+
+	buff := bytes.NewBuffer([]byte{})
+	if _, err := buff.ReadFrom(osResp.Body); err != nil {
+		return fmt.Errorf("failed to read search response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), response); err != nil {
+		return fmt.Errorf("failed to unmarshal search response: %w", err)
+	}
+
+	return nil
+}
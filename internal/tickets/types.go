@@ -0,0 +1,111 @@
+package tickets
+
+import (
+	"time"
+)
+
+// VelocityWindow is how far back ticket sales are measured when calculating how fast an event is selling
+const VelocityWindow = 24 * time.Hour
+
+// Snapshot is the ticket counts of an event as of a change log run.
+// Snapshots are only recorded when the available tickets change, so the
+// counts hold from the snapshot's date until the next snapshot.
+type Snapshot struct {
+	ID               string `json:"id"`
+	GameID           string `json:"gameId"`
+	ChangeLogID      string `json:"changeLogId"`
+	Date             string `json:"date"`
+	TicketsAvailable int64  `json:"ticketsAvailable"`
+	TotalTickets     int64  `json:"totalTickets"`
+}
+
+// NewSnapshot instantiates a [Snapshot] of the event's ticket counts for the change log.
+// The ID is derived from both, so recording the same change log run again overwrites it.
+func NewSnapshot(gameID, changeLogID, date string, ticketsAvailable, totalTickets int64) *Snapshot {
+	return &Snapshot{
+		ID:               gameID + "_" + changeLogID,
+		GameID:           gameID,
+		ChangeLogID:      changeLogID,
+		Date:             date,
+		TicketsAvailable: ticketsAvailable,
+		TotalTickets:     totalTickets,
+	}
+}
+
+// Metrics are derived from the ticket history of an event.
+type Metrics struct {
+	Sold        int64
+	PercentSold float64
+	// SoldPerHour is the sell-through velocity over the last [VelocityWindow]
+	SoldPerHour float64
+	// ProjectedSellOut is when the event sells out if it keeps selling at SoldPerHour.
+	// It is nil when the event is not selling or is already sold out.
+	ProjectedSellOut *time.Time
+}
+
+// Compute the metrics of an event currently at available of total tickets, given its
+// snapshot history sorted oldest first.
+func Compute(history []*Snapshot, available, total int64, now time.Time) Metrics {
+	m := Metrics{
+		Sold:        max(total-available, 0),
+		SoldPerHour: SoldPerHour(Baseline(history, now), available, now),
+	}
+
+	if total > 0 {
+		m.PercentSold = float64(m.Sold) / float64(total) * 100
+	}
+
+	if m.SoldPerHour > 0 && available > 0 {
+		sellOut := now.Add(time.Duration(float64(available) / m.SoldPerHour * float64(time.Hour)))
+		m.ProjectedSellOut = &sellOut
+	}
+
+	return m
+}
+
+// Baseline finds the snapshot sales are measured from: the latest snapshot from before the
+// [VelocityWindow], or the oldest snapshot when the history starts inside of the window.
+// The history must be sorted oldest first.
+func Baseline(history []*Snapshot, now time.Time) *Snapshot {
+	windowStart := now.Add(-VelocityWindow)
+
+	var baseline *Snapshot
+	for _, s := range history {
+		date, err := time.Parse(time.RFC3339, s.Date)
+		if err != nil {
+			continue
+		}
+
+		if baseline != nil && date.After(windowStart) {
+			break
+		}
+
+		baseline = s
+	}
+
+	return baseline
+}
+
+// SoldPerHour is the tickets sold per hour from the baseline until now, when there are available tickets.
+// Tickets added back to the event do not count as negative sales.
+func SoldPerHour(baseline *Snapshot, available int64, now time.Time) float64 {
+	if baseline == nil {
+		return 0
+	}
+
+	start, err := time.Parse(time.RFC3339, baseline.Date)
+	if err != nil {
+		return 0
+	}
+
+	if windowStart := now.Add(-VelocityWindow); start.Before(windowStart) {
+		start = windowStart
+	}
+
+	hours := now.Sub(start).Hours()
+	if hours <= 0 {
+		return 0
+	}
+
+	return float64(max(baseline.TicketsAvailable-available, 0)) / hours
+}
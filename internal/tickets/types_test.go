@@ -0,0 +1,152 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func snapshot(date time.Time, available int64) *Snapshot {
+	return NewSnapshot("RPG25ND000001", date.Format(time.RFC3339), date.Format(time.RFC3339), available, 100)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestBaseline(t *testing.T) {
+	now := time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		history []*Snapshot
+		want    int // index into history, -1 for nil
+	}{
+		{
+			name: "no history",
+			want: -1,
+		},
+		{
+			name: "latest snapshot before the window",
+			history: []*Snapshot{
+				snapshot(now.Add(-72*time.Hour), 100),
+				snapshot(now.Add(-30*time.Hour), 90),
+				snapshot(now.Add(-2*time.Hour), 50),
+			},
+			want: 1,
+		},
+		{
+			name: "history starts inside the window",
+			history: []*Snapshot{
+				snapshot(now.Add(-10*time.Hour), 100),
+				snapshot(now.Add(-2*time.Hour), 50),
+			},
+			want: 0,
+		},
+		{
+			name: "snapshot exactly at the window start",
+			history: []*Snapshot{
+				snapshot(now.Add(-48*time.Hour), 100),
+				snapshot(now.Add(-VelocityWindow), 80),
+				snapshot(now.Add(-time.Hour), 70),
+			},
+			want: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Baseline(tc.history, now)
+			if tc.want < 0 {
+				require.Nil(t, got)
+				return
+			}
+
+			require.Equal(t, tc.history[tc.want], got)
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	now := time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		history   []*Snapshot
+		available int64
+		total     int64
+		want      Metrics
+	}{
+		{
+			name:      "no history",
+			available: 40,
+			total:     100,
+			want:      Metrics{Sold: 60, PercentSold: 60},
+		},
+		{
+			name: "selling over the whole window",
+			history: []*Snapshot{
+				snapshot(now.Add(-48*time.Hour), 100),
+				snapshot(now.Add(-6*time.Hour), 60),
+			},
+			available: 52,
+			total:     100,
+			want: Metrics{
+				Sold:             48,
+				PercentSold:      48,
+				SoldPerHour:      2,
+				ProjectedSellOut: timePtr(now.Add(26 * time.Hour)),
+			},
+		},
+		{
+			name: "selling since the first snapshot",
+			history: []*Snapshot{
+				snapshot(now.Add(-4*time.Hour), 20),
+			},
+			available: 10,
+			total:     20,
+			want: Metrics{
+				Sold:             10,
+				PercentSold:      50,
+				SoldPerHour:      2.5,
+				ProjectedSellOut: timePtr(now.Add(4 * time.Hour)),
+			},
+		},
+		{
+			name: "sold out",
+			history: []*Snapshot{
+				snapshot(now.Add(-12*time.Hour), 12),
+			},
+			available: 0,
+			total:     12,
+			want:      Metrics{Sold: 12, PercentSold: 100, SoldPerHour: 1},
+		},
+		{
+			name: "tickets added back",
+			history: []*Snapshot{
+				snapshot(now.Add(-12*time.Hour), 5),
+			},
+			available: 15,
+			total:     10,
+			want:      Metrics{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Compute(tc.history, tc.available, tc.total, now)
+			require.Equal(t, tc.want.Sold, got.Sold)
+			require.InDelta(t, tc.want.PercentSold, got.PercentSold, 0.001)
+			require.InDelta(t, tc.want.SoldPerHour, got.SoldPerHour, 0.001)
+
+			if tc.want.ProjectedSellOut == nil {
+				require.Nil(t, got.ProjectedSellOut)
+				return
+			}
+
+			require.NotNil(t, got.ProjectedSellOut)
+			require.WithinDuration(t, *tc.want.ProjectedSellOut, *got.ProjectedSellOut, time.Second)
+		})
+	}
+}
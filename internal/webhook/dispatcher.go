@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultDispatchTimeout     = 30 * time.Second
+	defaultDispatchConcurrency = 8
+)
+
+// PayloadFunc builds the body sent to an endpoint
+type PayloadFunc func(endpoint *Endpoint) ([]byte, error)
+
+// Dispatcher sends webhooks to every registered endpoint and logs each delivery.
+// Timeout bounds how long Dispatch spends sending, and Concurrency is how many
+// endpoints are sent to at once.
+type Dispatcher struct {
+	logger      *zerolog.Logger
+	repo        *Repo
+	sender      *Sender
+	Timeout     time.Duration
+	Concurrency int
+}
+
+// NewDispatcher instantiates a new Dispatcher with the default timeout and concurrency
+func NewDispatcher(logger *zerolog.Logger, repo *Repo, sender *Sender) *Dispatcher {
+	return &Dispatcher{
+		logger:      logger,
+		repo:        repo,
+		sender:      sender,
+		Timeout:     defaultDispatchTimeout,
+		Concurrency: defaultDispatchConcurrency,
+	}
+}
+
+// Dispatch sends the event for the change log to every endpoint, several at a time. A delivery is logged
+// for each endpoint, whether or not it succeeded, so failures can be replayed later. Deliveries still
+// retrying when the timeout is reached are logged as failed, leaving them to replay.
+// The returned error only covers failing to build or log deliveries, a receiver rejecting the webhook
+// is recorded on its delivery.
+func (d *Dispatcher) Dispatch(ctx context.Context, event, changeLogID string, payload PayloadFunc) ([]*Delivery, error) {
+	endpoints, err := d.repo.Endpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the webhook endpoints: %w", err)
+	}
+
+	var (
+		deliveries []*Delivery
+		errs       []error
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		slots      = make(chan struct{}, max(d.Concurrency, 1))
+	)
+
+	// deliveries are still logged with ctx once sending runs out of time
+	sendCtx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+
+	for _, endpoint := range endpoints {
+		body, err := payload(endpoint)
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("failed to build the webhook payload for endpoint %s: %w", endpoint.ID, err))
+			mu.Unlock()
+			continue
+		}
+
+		delivery := NewDelivery(endpoint.ID, event, changeLogID, body)
+		deliveries = append(deliveries, delivery)
+
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+
+			if err := d.deliver(sendCtx, ctx, endpoint, delivery); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return deliveries, errors.Join(errs...)
+}
+
+// Replay resends the deliveries with their original payload and delivery id,
+// signed with the endpoint's current secret. Deliveries to endpoints that
+// have since been removed are skipped.
+func (d *Dispatcher) Replay(ctx context.Context, deliveries ...*Delivery) error {
+	var errs []error
+
+	for _, delivery := range deliveries {
+		endpoint, err := d.repo.GetEndpoint(ctx, delivery.EndpointID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch webhook endpoint %s: %w", delivery.EndpointID, err))
+			continue
+		}
+
+		if endpoint == nil {
+			d.logger.Warn().
+				Str("delivery_id", delivery.ID).
+				Str("endpoint_id", delivery.EndpointID).
+				Msg("webhook endpoint no longer exists, skipping replay")
+			continue
+		}
+
+		if err := d.deliver(ctx, ctx, endpoint, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver sends the delivery with sendCtx, and logs it with ctx
+func (d *Dispatcher) deliver(sendCtx, ctx context.Context, endpoint *Endpoint, delivery *Delivery) error {
+	delivery.record(d.sender.Send(sendCtx, endpoint, delivery))
+
+	if delivery.Status == Failed {
+		d.logger.Warn().
+			Str("delivery_id", delivery.ID).
+			Str("endpoint_id", endpoint.ID).
+			Int("attempts", delivery.Attempts).
+			Int("status_code", delivery.StatusCode).
+			Msgf("webhook delivery failed: %s", delivery.Error)
+	}
+
+	if err := d.repo.WriteDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to log webhook delivery %s: %w", delivery.ID, err)
+	}
+
+	return nil
+}
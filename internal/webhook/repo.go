@@ -0,0 +1,328 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/rs/zerolog"
+)
+
+// Repo controls talking to the OpenSearch cluster for webhook endpoints and their deliveries
+type Repo struct {
+	logger        *zerolog.Logger
+	client        *opensearch.Client
+	batchSize     int
+	endpointIndex string
+	deliveryIndex string
+}
+
+// NewRepo instantiates a new Repo
+func NewRepo(logger *zerolog.Logger, client *opensearch.Client, batchSize int, endpointIndex, deliveryIndex string) *Repo {
+	return &Repo{
+		logger:        logger,
+		client:        client,
+		batchSize:     batchSize,
+		endpointIndex: endpointIndex,
+		deliveryIndex: deliveryIndex,
+	}
+}
+
+// CreateEndpoint stores a new endpoint
+func (r *Repo) CreateEndpoint(ctx context.Context, e *Endpoint) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook endpoint %s: %w", e.ID, err)
+	}
+
+	req := opensearchapi.CreateRequest{
+		Index:      r.endpointIndex,
+		DocumentID: e.ID,
+		Body:       bytes.NewReader(body),
+		Refresh:    "true",
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close create webhook endpoint response body")
+		}
+	}()
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("create webhook endpoint request failed. Raw response: %s", resp.String())
+		return fmt.Errorf("failed create webhook endpoint request %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GetEndpoint fetches an endpoint, returning nil if it does not exist
+func (r *Repo) GetEndpoint(ctx context.Context, id string) (*Endpoint, error) {
+	req := opensearchapi.GetRequest{
+		Index:      r.endpointIndex,
+		DocumentID: id,
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close get webhook endpoint response body")
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("get webhook endpoint request failed. Raw response: %s", resp.String())
+		return nil, fmt.Errorf("failed get webhook endpoint request %d", resp.StatusCode)
+	}
+
+	var (
+		response struct {
+			Found    bool      `json:"found"`
+			Endpoint *Endpoint `json:"_source,omitempty"`
+		}
+		buff = bytes.NewBuffer([]byte{})
+	)
+
+	if _, err := buff.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read get webhook endpoint response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal get webhook endpoint response: %w", err)
+	}
+
+	if !response.Found {
+		return nil, nil
+	}
+
+	return response.Endpoint, nil
+}
+
+// DeleteEndpoint removes an endpoint, returning false if it did not exist.
+// Its deliveries are kept.
+func (r *Repo) DeleteEndpoint(ctx context.Context, id string) (bool, error) {
+	req := opensearchapi.DeleteRequest{
+		Index:      r.endpointIndex,
+		DocumentID: id,
+		Refresh:    "true",
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close delete webhook endpoint response body")
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("delete webhook endpoint request failed. Raw response: %s", resp.String())
+		return false, fmt.Errorf("failed delete webhook endpoint request %d", resp.StatusCode)
+	}
+
+	return true, nil
+}
+
+// Endpoints fetches every endpoint, paging through them in batches
+func (r *Repo) Endpoints(ctx context.Context) ([]*Endpoint, error) {
+	if r.batchSize <= 0 {
+		return nil, fmt.Errorf("batch size cannot be less than 1, got %d", r.batchSize)
+	}
+
+	var (
+		all         []*Endpoint
+		searchAfter []any
+	)
+
+	for {
+		body := map[string]any{
+			"size":  r.batchSize,
+			"query": map[string]any{"match_all": map[string]any{}},
+			"sort": []any{
+				map[string]any{"id": map[string]any{"order": "asc"}},
+			},
+		}
+
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+
+		var response struct {
+			Hits struct {
+				Hits []struct {
+					Endpoint *Endpoint `json:"_source,omitempty"`
+					Sort     []any     `json:"sort,omitempty"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+
+		if err := r.search(ctx, r.endpointIndex, body, &response); err != nil {
+			return nil, err
+		}
+
+		for _, h := range response.Hits.Hits {
+			all = append(all, h.Endpoint)
+		}
+
+		hits := response.Hits.Hits
+		if len(hits) < r.batchSize {
+			return all, nil
+		}
+
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+// WriteDelivery stores the delivery, overwriting any previous record of it
+func (r *Repo) WriteDelivery(ctx context.Context, d *Delivery) error {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook delivery %s: %w", d.ID, err)
+	}
+
+	req := opensearchapi.IndexRequest{
+		Index:      r.deliveryIndex,
+		DocumentID: d.ID,
+		Body:       bytes.NewReader(body),
+		Refresh:    "true",
+	}
+
+	resp, err := req.Do(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close write webhook delivery response body")
+		}
+	}()
+
+	if resp.IsError() {
+		r.logger.Error().Msgf("write webhook delivery request failed. Raw response: %s", resp.String())
+		return fmt.Errorf("failed write webhook delivery request %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// FetchDeliveries fetches the deliveries by id, skipping any that do not exist
+func (r *Repo) FetchDeliveries(ctx context.Context, ids ...string) ([]*Delivery, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	body := map[string]any{
+		"size": len(ids),
+		"query": map[string]any{
+			"ids": map[string]any{"values": ids},
+		},
+		"sort": []any{
+			map[string]any{"date": map[string]any{"order": "asc"}},
+			map[string]any{"id": map[string]any{"order": "asc"}},
+		},
+	}
+
+	return r.deliveries(ctx, body)
+}
+
+// FailedDeliveries lists up to limit failed deliveries, oldest first
+func (r *Repo) FailedDeliveries(ctx context.Context, limit int) ([]*Delivery, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit cannot be less than 1, got %d", limit)
+	}
+
+	body := map[string]any{
+		"size": limit,
+		"query": map[string]any{
+			"term": map[string]any{"status": Failed},
+		},
+		"sort": []any{
+			map[string]any{"date": map[string]any{"order": "asc"}},
+			map[string]any{"id": map[string]any{"order": "asc"}},
+		},
+	}
+
+	return r.deliveries(ctx, body)
+}
+
+func (r *Repo) deliveries(ctx context.Context, body map[string]any) ([]*Delivery, error) {
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Delivery *Delivery `json:"_source,omitempty"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := r.search(ctx, r.deliveryIndex, body, &response); err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*Delivery, len(response.Hits.Hits))
+	for i, h := range response.Hits.Hits {
+		deliveries[i] = h.Delivery
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repo) search(ctx context.Context, index string, body map[string]any, response any) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search request: %w", err)
+	}
+
+	r.logger.Debug().Msgf("Performing search request on %s: %s", index, bodyBytes)
+
+	osReq := opensearchapi.SearchRequest{
+		Index: []string{index},
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	osResp, err := osReq.Do(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := osResp.Body.Close(); err != nil {
+			r.logger.Err(err).Msg("failed to close search response body")
+		}
+	}()
+
+	if osResp.IsError() {
+		r.logger.Error().Msgf("search request failed. Raw response: %s", osResp.String())
+		return fmt.Errorf("failed search request %d", osResp.StatusCode)
+	}
+
+	buff := bytes.NewBuffer([]byte{})
+	if _, err := buff.ReadFrom(osResp.Body); err != nil {
+		return fmt.Errorf("failed to read search response body: %w", err)
+	}
+
+	if err := json.Unmarshal(buff.Bytes(), response); err != nil {
+		return fmt.Errorf("failed to unmarshal search response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed by the endpoint secret
+	SignatureHeader = "X-GCB-Signature"
+	// DeliveryHeader carries the delivery id, which stays the same when a delivery is retried or replayed
+	DeliveryHeader = "X-GCB-Delivery"
+	// EventHeader carries the webhook event name
+	EventHeader = "X-GCB-Event"
+
+	defaultTimeout        = 10 * time.Second
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// Sender posts signed webhooks, retrying failed attempts with exponential backoff.
+// The zero value is not usable; construct it with NewSender (or set the fields directly in tests).
+type Sender struct {
+	HTTPClient     *http.Client
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// NewSender returns a Sender with the default timeout, attempts and backoff
+func NewSender() *Sender {
+	return &Sender{
+		HTTPClient:     &http.Client{Timeout: defaultTimeout},
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+	}
+}
+
+// Result is the outcome of sending a webhook
+type Result struct {
+	Attempts int
+	// StatusCode is the response status of the last attempt, if there was a response
+	StatusCode int
+	// Err is nil when the receiver responded with a 2xx status
+	Err error
+}
+
+// Sign returns the signature of the body for the SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the body to the endpoint. Network errors, 429 and 5xx responses are retried
+// until MaxAttempts is reached, while any other non 2xx response fails immediately.
+func (s *Sender) Send(ctx context.Context, endpoint *Endpoint, delivery *Delivery) Result {
+	var (
+		result  Result
+		body    = []byte(delivery.Payload)
+		backoff = s.InitialBackoff
+	)
+
+	for result.Attempts < s.MaxAttempts {
+		if result.Attempts > 0 {
+			select {
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+				return result
+			case <-time.After(backoff):
+			}
+
+			backoff = min(backoff*2, s.MaxBackoff)
+		}
+
+		result.Attempts++
+
+		var retry bool
+		result.StatusCode, retry, result.Err = s.post(ctx, endpoint, delivery, body)
+		if result.Err == nil || !retry {
+			return result
+		}
+	}
+
+	return result
+}
+
+func (s *Sender) post(ctx context.Context, endpoint *Endpoint, delivery *Delivery, body []byte) (int, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(EventHeader, delivery.Event)
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return 0, ctx.Err() == nil, fmt.Errorf("webhook request: %w", err)
+	}
+
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return resp.StatusCode, false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return resp.StatusCode, true, fmt.Errorf("webhook receiver responded with status %d", resp.StatusCode)
+	default:
+		return resp.StatusCode, false, fmt.Errorf("webhook receiver responded with status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSender(srv *httptest.Server) *Sender {
+	return &Sender{
+		HTTPClient:     srv.Client(),
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}
+}
+
+func TestNewSender_Defaults(t *testing.T) {
+	s := NewSender()
+	require.NotNil(t, s.HTTPClient)
+	require.Equal(t, defaultMaxAttempts, s.MaxAttempts)
+	require.Equal(t, defaultInitialBackoff, s.InitialBackoff)
+	require.Equal(t, defaultMaxBackoff, s.MaxBackoff)
+}
+
+func TestSend_SignsTheBody(t *testing.T) {
+	const payload = `{"event":"changelog.created"}`
+
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotDelivery  string
+		gotEvent     string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		gotBody, err = io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotDelivery = r.Header.Get(DeliveryHeader)
+		gotEvent = r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	endpoint := &Endpoint{ID: "endpoint", URL: srv.URL, Secret: "shh"}
+	delivery := NewDelivery(endpoint.ID, ChangeLogCreated, "changelog", []byte(payload))
+
+	result := testSender(srv).Send(context.Background(), endpoint, delivery)
+	require.NoError(t, result.Err)
+	require.Equal(t, 1, result.Attempts)
+	require.Equal(t, http.StatusNoContent, result.StatusCode)
+
+	require.Equal(t, payload, string(gotBody))
+	require.Equal(t, Sign("shh", gotBody), gotSignature)
+	require.Equal(t, "sha256=515aae133b435d4000956731f68ae5cf5eb85d4f0dc6a546d2bfcd3595ec1ae1", Sign("key", []byte("body")))
+	require.Equal(t, delivery.ID, gotDelivery)
+	require.Equal(t, ChangeLogCreated, gotEvent)
+}
+
+func TestSend_Retries(t *testing.T) {
+	testCases := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantStatus   int
+		wantErr      bool
+	}{
+		{
+			name:         "succeeds after server errors",
+			statuses:     []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			wantAttempts: 3,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "retries rate limits",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusAccepted},
+			wantAttempts: 2,
+			wantStatus:   http.StatusAccepted,
+		},
+		{
+			name:         "gives up after max attempts",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 3,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry client errors",
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			wantAttempts: 1,
+			wantStatus:   http.StatusBadRequest,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := int(calls.Add(1)) - 1
+				w.WriteHeader(tc.statuses[i])
+			}))
+			defer srv.Close()
+
+			endpoint := &Endpoint{ID: "endpoint", URL: srv.URL, Secret: "shh"}
+			delivery := NewDelivery(endpoint.ID, ChangeLogCreated, "changelog", []byte(`{}`))
+
+			result := testSender(srv).Send(context.Background(), endpoint, delivery)
+			require.Equal(t, tc.wantAttempts, result.Attempts)
+			require.Equal(t, tc.wantAttempts, int(calls.Load()))
+			require.Equal(t, tc.wantStatus, result.StatusCode)
+
+			if tc.wantErr {
+				require.Error(t, result.Err)
+			} else {
+				require.NoError(t, result.Err)
+			}
+
+			delivery.record(result)
+			if tc.wantErr {
+				require.Equal(t, Failed, delivery.Status)
+				require.NotEmpty(t, delivery.Error)
+			} else {
+				require.Equal(t, Succeeded, delivery.Status)
+				require.Empty(t, delivery.Error)
+			}
+		})
+	}
+}
+
+func TestSend_RetriesUnreachableEndpoints(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	endpoint := &Endpoint{ID: "endpoint", URL: srv.URL, Secret: "shh"}
+	delivery := NewDelivery(endpoint.ID, ChangeLogCreated, "changelog", []byte(`{}`))
+
+	result := testSender(srv).Send(context.Background(), endpoint, delivery)
+	require.Error(t, result.Err)
+	require.Equal(t, 3, result.Attempts)
+	require.Zero(t, result.StatusCode)
+}
+
+func TestNewEndpoint(t *testing.T) {
+	testCases := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "https", url: "https://example.com/hooks/gcb"},
+		{name: "http", url: "http://localhost:8080/hook"},
+		{name: "missing scheme", url: "example.com/hook", wantErr: true},
+		{name: "unsupported scheme", url: "ftp://example.com/hook", wantErr: true},
+		{name: "missing host", url: "https:///hook", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := NewEndpoint(tc.url, "", true)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.url, e.URL)
+			require.Len(t, e.Secret, 64)
+			require.True(t, e.IncludeEvents)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChangeLogCreated is the webhook event sent when a change log entry is created
+const ChangeLogCreated = "changelog.created"
+
+// DeliveryStatus is the outcome of delivering a webhook
+type DeliveryStatus string
+
+const (
+	Succeeded DeliveryStatus = "succeeded"
+	Failed    DeliveryStatus = "failed"
+)
+
+// Endpoint is a registered receiver of webhooks.
+type Endpoint struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret signs every delivery to the endpoint
+	Secret string `json:"secret"`
+	// IncludeEvents adds the hydrated events to the payload, rather than only the change log summary
+	IncludeEvents bool   `json:"includeEvents"`
+	Date          string `json:"date"`
+}
+
+// Delivery is the log of sending a single webhook to an endpoint, including
+// the payload so failed deliveries can be replayed.
+type Delivery struct {
+	ID          string         `json:"id"`
+	EndpointID  string         `json:"endpointId"`
+	Event       string         `json:"event"`
+	ChangeLogID string         `json:"changeLogId"`
+	Date        string         `json:"date"`
+	Status      DeliveryStatus `json:"status"`
+	Attempts    int            `json:"attempts"`
+	StatusCode  int            `json:"statusCode,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Payload     string         `json:"payload"`
+}
+
+// NewEndpoint validates the url and instantiates an [Endpoint] with a UUID for the ID
+// and a date timestamp of now. A random secret is generated when none is provided.
+func NewEndpoint(rawURL, secret string, includeEvents bool) (*Endpoint, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook url must be an absolute http or https url, got %q", rawURL)
+	}
+
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate a webhook secret: %w", err)
+		}
+
+		secret = hex.EncodeToString(b)
+	}
+
+	return &Endpoint{
+		ID:            uuid.Must(uuid.NewV7()).String(),
+		URL:           u.String(),
+		Secret:        secret,
+		IncludeEvents: includeEvents,
+		Date:          time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// NewDelivery instantiates a [Delivery] of the payload to the endpoint
+// with a UUID for the ID and a date timestamp of now.
+func NewDelivery(endpointID, event, changeLogID string, payload []byte) *Delivery {
+	return &Delivery{
+		ID:          uuid.Must(uuid.NewV7()).String(),
+		EndpointID:  endpointID,
+		Event:       event,
+		ChangeLogID: changeLogID,
+		Date:        time.Now().Format(time.RFC3339),
+		Payload:     string(payload),
+	}
+}
+
+// record updates the delivery with the result of sending it
+func (d *Delivery) record(result Result) {
+	d.Attempts += result.Attempts
+	d.StatusCode = result.StatusCode
+	d.Error = ""
+	d.Status = Succeeded
+
+	if result.Err != nil {
+		d.Status = Failed
+		d.Error = result.Err.Error()
+	}
+}